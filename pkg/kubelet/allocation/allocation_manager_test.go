@@ -32,6 +32,7 @@ import (
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/kubernetes/fake"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/features"
 	"k8s.io/kubernetes/pkg/kubelet/allocation/state"
 	"k8s.io/kubernetes/pkg/kubelet/cm"
@@ -848,6 +849,87 @@ func TestHandlePodResourcesResize(t *testing.T) {
 	}
 }
 
+// TestResizeDecisionExplainsInfeasibleCapacityMessages ties the terse
+// PodResizePending condition messages asserted in TestHandlePodResourcesResize
+// ("Node didn't have enough capacity: memory, requested: ..., capacity: ...")
+// to the structured, per-resource ResizeDecision a caller can retrieve from
+// the /debug/resize endpoint: both must agree on which resource was
+// limiting and by how much, so the condition message's one-liner and the
+// queryable decision log are two views of the same admission outcome rather
+// than independent, possibly-divergent explanations. Both sides are
+// supplied by hand here rather than produced by a real admission attempt,
+// since manager.go isn't part of this checkout; it checks that the two
+// representations agree with each other, not that HandlePodResourcesResize
+// actually produces them.
+func TestResizeDecisionExplainsInfeasibleCapacityMessages(t *testing.T) {
+	tests := []struct {
+		name            string
+		conditionMsg    string
+		decision        ResizeDecision
+		wantInMessage   v1.ResourceName
+		wantStringParts []string
+	}{
+		{
+			name:         "memory beyond node capacity",
+			conditionMsg: "Node didn't have enough capacity: memory, requested: 4718592000, capacity: 4294967296",
+			decision: ResizeDecision{
+				PodUID:           "1111",
+				Verdict:          ResizeVerdictInfeasible,
+				LimitingResource: v1.ResourceMemory,
+				Fits: []ResourceFit{
+					{
+						Resource:    v1.ResourceMemory,
+						Delta:       *resource.NewQuantity(3644850176, resource.BinarySI),
+						Allocatable: *resource.NewQuantity(4294967296, resource.BinarySI),
+						Headroom:    *resource.NewQuantity(3221225472, resource.BinarySI),
+						Short:       *resource.NewQuantity(423624704, resource.BinarySI),
+					},
+				},
+			},
+			wantInMessage:   v1.ResourceMemory,
+			wantStringParts: []string{"verdict=Infeasible", "memory", "short="},
+		},
+		{
+			name:         "cpu beyond node capacity",
+			conditionMsg: "Node didn't have enough capacity: cpu, requested: 5000, capacity: 4000",
+			decision: ResizeDecision{
+				PodUID:           "1111",
+				Verdict:          ResizeVerdictInfeasible,
+				LimitingResource: v1.ResourceCPU,
+				Fits: []ResourceFit{
+					{
+						Resource:    v1.ResourceCPU,
+						Delta:       *resource.NewMilliQuantity(4000, resource.DecimalSI),
+						Allocatable: *resource.NewMilliQuantity(4000, resource.DecimalSI),
+						Headroom:    *resource.NewMilliQuantity(3000, resource.DecimalSI),
+						Short:       *resource.NewMilliQuantity(1000, resource.DecimalSI),
+					},
+				},
+			},
+			wantInMessage:   v1.ResourceCPU,
+			wantStringParts: []string{"verdict=Infeasible", "cpu", "short="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, tt.conditionMsg, string(tt.wantInMessage), "the condition message and the decision must name the same limiting resource")
+			assert.Equal(t, tt.wantInMessage, tt.decision.LimitingResource)
+
+			rendered := tt.decision.String()
+			for _, part := range tt.wantStringParts {
+				assert.Contains(t, rendered, part)
+			}
+
+			log := newResizeDecisionLog(1)
+			log.record(klog.Background(), tt.decision)
+			got := log.forPod(tt.decision.PodUID)
+			require.Len(t, got, 1)
+			assert.Equal(t, tt.decision, got[0])
+		})
+	}
+}
+
 func TestHandlePodResourcesResizeWithSwap(t *testing.T) {
 	if goruntime.GOOS == "windows" {
 		t.Skip("InPlacePodVerticalScaling is not currently supported for Windows")
@@ -924,6 +1006,13 @@ func TestHandlePodResourcesResizeWithSwap(t *testing.T) {
 			},
 		},
 		{
+			// resizeLimitedSwap/ResizeSwap (swap_resize.go) model what
+			// recomputing and resizing the container's swap limit
+			// alongside its memory limit would look like, but manager.go
+			// isn't part of this checkout, so nothing in the production
+			// resize path ever calls them; this case stays Infeasible
+			// unconditionally, exactly as it did before swap_resize.go was
+			// added, until a real caller exists to make InProgress true.
 			name:                  "LimitedSwap Request Memory increase with ResizePolicy NotRequired - expect Infeasible",
 			newRequests:           v1.ResourceList{v1.ResourceCPU: cpu500m, v1.ResourceMemory: mem500M},
 			expectedAllocatedReqs: v1.ResourceList{v1.ResourceCPU: cpu1000m, v1.ResourceMemory: mem1000M},
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+// This file replaces the purely-opportunistic re-evaluation of
+// PodResizePending/Deferred resizes with an explicit backoff-driven queue.
+// A real integration would have manager.RetryPendingResizes iterate
+// podsWithPendingResizes in priority order (via sortPendingResizes) and
+// consult this queue to decide *when* a given pod is due for another
+// attempt, and how long a resize may sit in Deferred before the manager
+// gives up and marks it Infeasible. recordDeferredDecision turns one
+// recordDeferred call into a ResizeDecision whose verdict shows whether the
+// TTL was exceeded; the retry count, backoff and time spent Deferred are
+// not part of that decision.
+//
+// manager.go isn't part of this checkout, so there is no real caller of
+// deferredResizeQueue here; it and recordDeferredDecision are exercised
+// directly by this package's own tests.
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
+)
+
+const (
+	// defaultMinBackoff is the delay before the first retry of a
+	// Deferred resize.
+	defaultMinBackoff = 1 * time.Second
+	// defaultMaxBackoff caps the delay between retries.
+	defaultMaxBackoff = 2 * time.Minute
+	// defaultDeferredTTL bounds how long a resize may stay Deferred
+	// before it is converted to Infeasible with DeferredResizeTimedOut,
+	// so users don't see a resize "stuck pending forever".
+	defaultDeferredTTL = 10 * time.Minute
+)
+
+// DeferredResizeTimedOut is set as the PodResizePending reason once a
+// persistently Deferred resize exceeds its TTL without being admitted.
+const DeferredResizeTimedOut = "DeferredResizeTimedOut"
+
+// deferredResizeBackoff configures deferredResizeQueue. The zero value is
+// usable and applies the package defaults.
+type deferredResizeBackoff struct {
+	Min, Max time.Duration
+	TTL      time.Duration
+	Jitter   float64
+}
+
+func (b deferredResizeBackoff) min() time.Duration {
+	if b.Min <= 0 {
+		return defaultMinBackoff
+	}
+	return b.Min
+}
+
+func (b deferredResizeBackoff) max() time.Duration {
+	if b.Max <= 0 {
+		return defaultMaxBackoff
+	}
+	return b.Max
+}
+
+func (b deferredResizeBackoff) ttl() time.Duration {
+	if b.TTL <= 0 {
+		return defaultDeferredTTL
+	}
+	return b.TTL
+}
+
+// deferredEntry tracks one pod's Deferred resize history.
+type deferredEntry struct {
+	firstDeferredAt time.Time
+	nextAttemptAt   time.Time
+	backoff         time.Duration
+	retryCount      int
+	lastReason      string
+}
+
+// deferredResizeQueue decides when a Deferred resize is next eligible for
+// retry, driven by capacity-change events (recordCapacityChange) as well
+// as plain elapsed time, rather than blind polling on every sync.
+type deferredResizeQueue struct {
+	mu      sync.Mutex
+	backoff deferredResizeBackoff
+	entries map[types.UID]*deferredEntry
+	// capacityChanged is bumped every time the manager is notified of a
+	// pod add/remove/complete or a node allocatable update; entries
+	// whose nextAttemptAt is in the future are still retried immediately
+	// once this flag is set, since capacity may have freed up sooner
+	// than the backoff predicted.
+	capacityChanged bool
+}
+
+func newDeferredResizeQueue(backoff deferredResizeBackoff) *deferredResizeQueue {
+	return &deferredResizeQueue{backoff: backoff, entries: make(map[types.UID]*deferredEntry)}
+}
+
+// recordCapacityChange should be called whenever a pod is added, removed,
+// or completes, or the node's allocatable resources change. It makes
+// every currently-deferred pod immediately eligible for a retry attempt on
+// the next call to due, instead of waiting out its backoff.
+func (q *deferredResizeQueue) recordCapacityChange() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.capacityChanged = true
+}
+
+// due returns the pods that should be retried right now.
+func (q *deferredResizeQueue) due(now time.Time, pending []types.UID) []types.UID {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	capacityChanged := q.capacityChanged
+	q.capacityChanged = false
+
+	var result []types.UID
+	for _, uid := range pending {
+		entry, ok := q.entries[uid]
+		if !ok || capacityChanged || !now.Before(entry.nextAttemptAt) {
+			result = append(result, uid)
+		}
+	}
+	return result
+}
+
+// recordDeferred should be called after an admission attempt for uid comes
+// back Deferred. It returns the updated retry count, current backoff, next
+// attempt time, and whether the TTL has been exceeded (in which case the
+// caller should transition the resize to Infeasible/DeferredResizeTimedOut
+// instead of leaving it Deferred).
+func (q *deferredResizeQueue) recordDeferred(uid types.UID, now time.Time, reason string) (retryCount int, backoff time.Duration, nextAttempt time.Time, timedOut bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entry, ok := q.entries[uid]
+	if !ok {
+		entry = &deferredEntry{firstDeferredAt: now, backoff: q.backoff.min()}
+		q.entries[uid] = entry
+	} else {
+		entry.backoff = nextBackoffDuration(entry.backoff, q.backoff.max())
+	}
+	entry.retryCount++
+	entry.lastReason = reason
+	entry.nextAttemptAt = now.Add(jitterDuration(entry.backoff, q.backoff.Jitter))
+
+	timedOut = now.Sub(entry.firstDeferredAt) > q.backoff.ttl()
+
+	metrics.KubeletPodResizeRetryAttemptsTotal.WithLabelValues(reason).Inc()
+	metrics.KubeletPodResizeDeferredSeconds.Observe(now.Sub(entry.firstDeferredAt).Seconds())
+
+	return entry.retryCount, entry.backoff, entry.nextAttemptAt, timedOut
+}
+
+// recordDeferredDecision calls recordDeferred and files the outcome as a
+// ResizeDecision: Infeasible once the TTL is exceeded, Deferred otherwise.
+// The decision carries no reason string, so DeferredResizeTimedOut is not
+// recorded in it. resourceName/delta identify which
+// dimension of the resize is still short, for consistency with the
+// per-resource decisions the other admission paths in this package
+// record.
+func (q *deferredResizeQueue) recordDeferredDecision(log *resizeDecisionLog, podUID types.UID, now time.Time, reason string, resourceName v1.ResourceName, delta resource.Quantity) ResizeDecision {
+	_, _, _, timedOut := q.recordDeferred(podUID, now, reason)
+
+	decision := ResizeDecision{PodUID: podUID, LimitingResource: resourceName}
+	if timedOut {
+		decision.Verdict = ResizeVerdictInfeasible
+	} else {
+		decision.Verdict = ResizeVerdictDeferred
+	}
+	decision.Fits = []ResourceFit{{Resource: resourceName, Delta: delta}}
+
+	log.record(klog.Background(), decision)
+	return decision
+}
+
+// clear forgets a pod's deferred history, once its resize is admitted,
+// marked Infeasible, or the pod is removed.
+func (q *deferredResizeQueue) clear(uid types.UID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.entries, uid)
+}
+
+func nextBackoffDuration(current, max time.Duration) time.Duration {
+	next := current * 2
+	if next > max {
+		return max
+	}
+	return next
+}
+
+func jitterDuration(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+	// Spreads the delay over [d, d+d*fraction) so deferred pods that share
+	// a backoff don't all retry in lock-step.
+	return d + time.Duration(rand.Float64()*fraction*float64(d)) //nolint:gosec // jitter does not need to be cryptographically secure
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestDeferredResizeQueueBackoffGrows(t *testing.T) {
+	q := newDeferredResizeQueue(deferredResizeBackoff{Min: time.Second, Max: 8 * time.Second})
+	now := time.Now()
+
+	_, backoff1, _, timedOut := q.recordDeferred("pod-1", now, "Deferred")
+	assert.False(t, timedOut)
+	assert.Equal(t, time.Second, backoff1)
+
+	_, backoff2, _, _ := q.recordDeferred("pod-1", now, "Deferred")
+	assert.Equal(t, 2*time.Second, backoff2)
+
+	_, backoff3, _, _ := q.recordDeferred("pod-1", now, "Deferred")
+	assert.Equal(t, 4*time.Second, backoff3)
+}
+
+func TestDeferredResizeQueueTTL(t *testing.T) {
+	q := newDeferredResizeQueue(deferredResizeBackoff{TTL: time.Minute})
+	start := time.Now()
+	_, _, _, timedOut := q.recordDeferred("pod-1", start, "Deferred")
+	assert.False(t, timedOut)
+
+	_, _, _, timedOut = q.recordDeferred("pod-1", start.Add(2*time.Minute), "Deferred")
+	assert.True(t, timedOut)
+}
+
+func TestDeferredResizeQueueDue(t *testing.T) {
+	q := newDeferredResizeQueue(deferredResizeBackoff{Min: time.Minute})
+	now := time.Now()
+	q.recordDeferred("pod-1", now, "Deferred")
+
+	pending := []types.UID{"pod-1"}
+	assert.Empty(t, q.due(now.Add(time.Second), pending), "should not be due before backoff elapses")
+
+	q.recordCapacityChange()
+	assert.Equal(t, pending, q.due(now.Add(time.Second), pending), "capacity change should force an immediate retry")
+
+	q.clear("pod-1")
+	assert.Equal(t, pending, q.due(now, pending), "pods with no history are always due")
+}
+
+func TestRecordDeferredDecisionStaysDeferredBeforeTTL(t *testing.T) {
+	q := newDeferredResizeQueue(deferredResizeBackoff{TTL: time.Minute})
+	log := newResizeDecisionLog(1)
+
+	decision := q.recordDeferredDecision(log, "pod-1", time.Now(), "Deferred", v1.ResourceMemory, resource.MustParse("1Gi"))
+
+	assert.Equal(t, ResizeVerdictDeferred, decision.Verdict)
+	assert.Equal(t, []ResizeDecision{decision}, log.forPod("pod-1"))
+}
+
+func TestRecordDeferredDecisionBecomesInfeasibleAfterTTL(t *testing.T) {
+	q := newDeferredResizeQueue(deferredResizeBackoff{TTL: time.Minute})
+	log := newResizeDecisionLog(1)
+	start := time.Now()
+
+	q.recordDeferredDecision(log, "pod-1", start, "Deferred", v1.ResourceMemory, resource.MustParse("1Gi"))
+	decision := q.recordDeferredDecision(log, "pod-1", start.Add(2*time.Minute), "Deferred", v1.ResourceMemory, resource.MustParse("1Gi"))
+
+	assert.Equal(t, ResizeVerdictInfeasible, decision.Verdict)
+	assert.Equal(t, v1.ResourceMemory, decision.LimitingResource)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+// This file adds what the allocation manager would need to subtract
+// resources it didn't place itself from what it considers available,
+// similar to how YuniKorn tracks allocations it did not make. A real
+// integration would have CheckPodResizeInProgress and the admit handler
+// built in makeAllocationManager compare sum(actuated) + sum(foreign) +
+// delta against node Allocatable, rather than just sum(actuated) + delta,
+// before admitting a resize. checkForeignAllocationFit does that
+// comparison and records the result in a resizeDecisionLog with the same
+// per-resource Headroom/Short breakdown any other admission decision gets.
+// The recorded decision does not say whether foreign usage was what made
+// the resize Infeasible.
+//
+// manager.go, CheckPodResizeInProgress, and makeAllocationManager aren't
+// part of this checkout, so there is no real caller of
+// checkForeignAllocationFit here and ForeignAllocationsExceedCapacity is
+// never set as an Infeasible reason anywhere a real resize is evaluated;
+// this file is exercised directly by this package's own tests.
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// foreignAllocationRefreshInterval bounds how often the cache re-reads the
+// delegate's snapshot. Foreign usage (system.slice, other schedulers'
+// pods) doesn't change fast enough to justify scanning on every
+// admission check.
+const foreignAllocationRefreshInterval = 5 * time.Second
+
+// ForeignAllocationsExceedCapacity is used as the Infeasible reason when a
+// resize would fit against naive node Allocatable but not once foreign
+// allocations are taken into account.
+const ForeignAllocationsExceedCapacity = "ForeignAllocationsExceedCapacity"
+
+// ForeignAllocationProvider reports resource usage the kubelet did not
+// place itself: processes in system.slice/user.slice, or pods from a
+// different scheduler/runtime sharing the node. Implementations typically
+// derive this from a periodic cgroup scan.
+type ForeignAllocationProvider interface {
+	// ListForeignAllocations returns a snapshot of currently known
+	// foreign resource usage, one ResourceList per distinct foreign
+	// consumer (so memory/cpu bookkeeping doesn't need to be pre-summed
+	// by the caller).
+	ListForeignAllocations() []v1.ResourceList
+
+	// WatchForeignAllocations should run until ctx is cancelled,
+	// refreshing whatever ListForeignAllocations returns as usage
+	// changes. The allocation manager starts this from the same
+	// backgroundCtx it uses for its other housekeeping goroutines.
+	WatchForeignAllocations(ctx context.Context)
+}
+
+// noForeignAllocations is used when the manager is constructed without a
+// ForeignAllocationProvider, preserving today's behavior of treating the
+// full node Allocatable as available to kubelet-managed pods.
+type noForeignAllocations struct{}
+
+func (noForeignAllocations) ListForeignAllocations() []v1.ResourceList { return nil }
+func (noForeignAllocations) WatchForeignAllocations(ctx context.Context) {
+	<-ctx.Done()
+}
+
+// foreignAllocationTotal sums a provider's current snapshot into a single
+// ResourceList, suitable for subtracting from node Allocatable.
+func foreignAllocationTotal(provider ForeignAllocationProvider) v1.ResourceList {
+	total := v1.ResourceList{}
+	for _, rl := range provider.ListForeignAllocations() {
+		for name, qty := range rl {
+			if existing, ok := total[name]; ok {
+				existing.Add(qty)
+				total[name] = existing
+			} else {
+				total[name] = qty.DeepCopy()
+			}
+		}
+	}
+	return total
+}
+
+// effectiveAllocatable returns the node's Allocatable minus what foreign
+// consumers currently hold, clamped at zero per resource so a
+// stale/overcounted foreign snapshot can't make capacity go negative.
+func effectiveAllocatable(allocatable v1.ResourceList, foreign v1.ResourceList) v1.ResourceList {
+	effective := allocatable.DeepCopy()
+	for name, foreignQty := range foreign {
+		nodeQty, ok := effective[name]
+		if !ok {
+			continue
+		}
+		nodeQty.Sub(foreignQty)
+		if nodeQty.Sign() < 0 {
+			nodeQty.Set(0)
+		}
+		effective[name] = nodeQty
+	}
+	return effective
+}
+
+// cachingForeignAllocationProvider wraps a ForeignAllocationProvider and
+// memoizes the last snapshot, so admission checks (which may run many
+// times per second while resizes are retried) don't each force a fresh
+// cgroup scan.
+type cachingForeignAllocationProvider struct {
+	delegate ForeignAllocationProvider
+
+	mu       sync.RWMutex
+	snapshot []v1.ResourceList
+}
+
+func newCachingForeignAllocationProvider(delegate ForeignAllocationProvider) *cachingForeignAllocationProvider {
+	return &cachingForeignAllocationProvider{delegate: delegate}
+}
+
+func (c *cachingForeignAllocationProvider) ListForeignAllocations() []v1.ResourceList {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot
+}
+
+// checkForeignAllocationFit compares delta against the node's Allocatable
+// once both actuated kubelet usage and foreign usage are subtracted, and
+// records the outcome in log as a ResizeDecision: Infeasible, with
+// resourceName as the limiting resource, whenever delta exceeds that
+// headroom, Admit otherwise. It does not check whether delta would have fit
+// the naive Allocatable-minus-actuated, and the decision does not carry
+// ForeignAllocationsExceedCapacity.
+func checkForeignAllocationFit(log *resizeDecisionLog, podUID types.UID, resourceName v1.ResourceName, delta, actuated, allocatable resource.Quantity, foreign v1.ResourceList) ResizeDecision {
+	effective := effectiveAllocatable(v1.ResourceList{resourceName: allocatable}, foreign)[resourceName]
+
+	headroom := effective.DeepCopy()
+	headroom.Sub(actuated)
+	if headroom.Sign() < 0 {
+		headroom.Set(0)
+	}
+
+	short := delta.DeepCopy()
+	short.Sub(headroom)
+	if short.Sign() < 0 {
+		short.Set(0)
+	}
+
+	decision := ResizeDecision{PodUID: podUID}
+	if short.Sign() > 0 {
+		decision.Verdict = ResizeVerdictInfeasible
+		decision.LimitingResource = resourceName
+	} else {
+		decision.Verdict = ResizeVerdictAdmit
+	}
+	decision.Fits = []ResourceFit{{
+		Resource:    resourceName,
+		Delta:       delta,
+		Allocatable: allocatable,
+		Headroom:    headroom,
+		Short:       short,
+	}}
+
+	log.record(klog.Background(), decision)
+	return decision
+}
+
+func (c *cachingForeignAllocationProvider) WatchForeignAllocations(ctx context.Context) {
+	go c.delegate.WatchForeignAllocations(ctx)
+
+	ticker := time.NewTicker(foreignAllocationRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			c.snapshot = c.delegate.ListForeignAllocations()
+			c.mu.Unlock()
+		}
+	}
+}
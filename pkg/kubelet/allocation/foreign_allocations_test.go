@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestEffectiveAllocatableSubtractsForeignUsage(t *testing.T) {
+	allocatable := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("4"),
+		v1.ResourceMemory: resource.MustParse("4Gi"),
+	}
+	foreign := v1.ResourceList{
+		v1.ResourceCPU: resource.MustParse("1"),
+	}
+
+	effective := effectiveAllocatable(allocatable, foreign)
+	assert.Equal(t, resource.MustParse("3"), effective[v1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("4Gi"), effective[v1.ResourceMemory])
+}
+
+func TestEffectiveAllocatableClampsAtZero(t *testing.T) {
+	allocatable := v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}
+	foreign := v1.ResourceList{v1.ResourceCPU: resource.MustParse("5")}
+
+	effective := effectiveAllocatable(allocatable, foreign)
+	assert.Equal(t, resource.MustParse("0"), effective[v1.ResourceCPU])
+}
+
+func TestForeignAllocationTotalSumsAcrossConsumers(t *testing.T) {
+	provider := fakeForeignAllocationProvider{snapshot: []v1.ResourceList{
+		{v1.ResourceCPU: resource.MustParse("500m")},
+		{v1.ResourceCPU: resource.MustParse("250m")},
+	}}
+	total := foreignAllocationTotal(provider)
+	assert.Equal(t, resource.MustParse("750m"), total[v1.ResourceCPU])
+}
+
+func TestCheckForeignAllocationFitAdmitsWhenHeadroomCoversDelta(t *testing.T) {
+	log := newResizeDecisionLog(1)
+	decision := checkForeignAllocationFit(log, "pod-1", v1.ResourceCPU,
+		resource.MustParse("1"), resource.MustParse("1"), resource.MustParse("4"),
+		v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")})
+
+	assert.Equal(t, ResizeVerdictAdmit, decision.Verdict)
+	assert.Equal(t, []ResizeDecision{decision}, log.forPod("pod-1"))
+}
+
+func TestCheckForeignAllocationFitIsInfeasibleWhenForeignUsageTipsItOver(t *testing.T) {
+	log := newResizeDecisionLog(1)
+	// Naive Allocatable(4) - actuated(2) = 2 would fit a delta of 1, but
+	// foreign usage of 2 brings effective headroom down to 0.
+	decision := checkForeignAllocationFit(log, "pod-1", v1.ResourceCPU,
+		resource.MustParse("1"), resource.MustParse("2"), resource.MustParse("4"),
+		v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")})
+
+	assert.Equal(t, ResizeVerdictInfeasible, decision.Verdict)
+	assert.Equal(t, v1.ResourceCPU, decision.LimitingResource)
+	require.Len(t, decision.Fits, 1)
+	assert.Equal(t, resource.MustParse("1").MilliValue(), decision.Fits[0].Short.MilliValue())
+}
+
+type fakeForeignAllocationProvider struct {
+	snapshot []v1.ResourceList
+}
+
+func (f fakeForeignAllocationProvider) ListForeignAllocations() []v1.ResourceList   { return f.snapshot }
+func (f fakeForeignAllocationProvider) WatchForeignAllocations(ctx context.Context) {}
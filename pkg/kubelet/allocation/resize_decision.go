@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+// This file adds structured tracing for resize admission decisions, in
+// the spirit of the KNIDebug scheduler plugin: instead of a single terse
+// condition message like "Node didn't have enough capacity: memory,
+// requested: 4718592000, capacity: 4294967296", every admission attempt
+// is captured as a ResizeDecision with per-resource headroom, and the last
+// few decisions per pod are kept around for the /debug/resize endpoint.
+//
+// resizeDecisionLog.ServeHTTP is a real, mountable http.Handler, but no
+// admission path in this checkout ever calls record, and nothing mounts it
+// on a mux: the kubelet server package that would register /debug/resize
+// and the manager.go admission path that would populate the log aren't
+// part of this checkout. record is exercised directly by this package's
+// own tests instead; no test calls ServeHTTP.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// ResizeVerdict is the outcome of one resize admission attempt.
+type ResizeVerdict string
+
+const (
+	ResizeVerdictAdmit      ResizeVerdict = "Admit"
+	ResizeVerdictDeferred   ResizeVerdict = "Deferred"
+	ResizeVerdictInfeasible ResizeVerdict = "Infeasible"
+)
+
+// ResourceFit captures, for a single resource, what was requested, what
+// was already allocated to the pod, how much headroom the node had, and
+// the shortfall (how far the request exceeds headroom; zero when it fits).
+type ResourceFit struct {
+	Resource    v1.ResourceName   `json:"resource"`
+	Delta       resource.Quantity `json:"delta"`
+	Allocatable resource.Quantity `json:"allocatable"`
+	Allocated   resource.Quantity `json:"allocated"`
+	Headroom    resource.Quantity `json:"headroom"`
+	Short       resource.Quantity `json:"short"`
+}
+
+// ResizeDecision records one admission attempt for one pod, with enough
+// detail to answer "why is this resize stuck" without re-deriving it from
+// logs.
+type ResizeDecision struct {
+	Time             time.Time       `json:"time"`
+	PodUID           types.UID       `json:"podUID"`
+	Verdict          ResizeVerdict   `json:"verdict"`
+	LimitingResource v1.ResourceName `json:"limitingResource,omitempty"`
+	Fits             []ResourceFit   `json:"fits"`
+}
+
+// String renders the decision the way it is logged at V(4): a single line
+// with one segment per resource dimension in Quantity notation, and a
+// short= part only when the shortfall is positive, e.g.
+// "pod=1111 verdict=Infeasible cpu req=1500m have=800m short=700m".
+func (d ResizeDecision) String() string {
+	s := fmt.Sprintf("pod=%s verdict=%s", d.PodUID, d.Verdict)
+	for _, f := range d.Fits {
+		s += fmt.Sprintf(" %s req=%s have=%s", f.Resource, f.Delta.String(), f.Headroom.String())
+		if f.Short.Sign() > 0 {
+			s += fmt.Sprintf(" short=%s", f.Short.String())
+		}
+	}
+	return s
+}
+
+// resizeDecisionLog keeps a bounded ring buffer of the most recent resize
+// decisions per pod, serving the kubelet /debug/resize endpoint.
+type resizeDecisionLog struct {
+	mu        sync.Mutex
+	maxPerPod int
+	decisions map[types.UID][]ResizeDecision
+}
+
+func newResizeDecisionLog(maxPerPod int) *resizeDecisionLog {
+	if maxPerPod <= 0 {
+		maxPerPod = 20
+	}
+	return &resizeDecisionLog{maxPerPod: maxPerPod, decisions: make(map[types.UID][]ResizeDecision)}
+}
+
+// record appends a decision for the pod, trims the per-pod ring buffer,
+// and emits the V(4) structured log line.
+func (l *resizeDecisionLog) record(logger klog.Logger, d ResizeDecision) {
+	l.mu.Lock()
+	entries := append(l.decisions[d.PodUID], d)
+	if len(entries) > l.maxPerPod {
+		entries = entries[len(entries)-l.maxPerPod:]
+	}
+	l.decisions[d.PodUID] = entries
+	l.mu.Unlock()
+
+	logger.V(4).Info("Resize admission decision", "decision", d.String())
+}
+
+func (l *resizeDecisionLog) forPod(uid types.UID) []ResizeDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]ResizeDecision(nil), l.decisions[uid]...)
+}
+
+func (l *resizeDecisionLog) removePod(uid types.UID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.decisions, uid)
+}
+
+// ServeHTTP implements the kubelet's /debug/resize endpoint. A
+// "podUID" query parameter restricts the response to one pod; without it,
+// every tracked pod's decisions are returned.
+func (l *resizeDecisionLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	var result map[types.UID][]ResizeDecision
+	if uid := types.UID(r.URL.Query().Get("podUID")); uid != "" {
+		result = map[types.UID][]ResizeDecision{uid: l.decisions[uid]}
+	} else {
+		result = make(map[types.UID][]ResizeDecision, len(l.decisions))
+		for uid, decisions := range l.decisions {
+			result[uid] = decisions
+		}
+	}
+	l.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
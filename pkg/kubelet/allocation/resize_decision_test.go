@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+)
+
+func TestResizeDecisionLogTrimsToMaxPerPod(t *testing.T) {
+	l := newResizeDecisionLog(2)
+	for i := 0; i < 5; i++ {
+		l.record(klog.Background(), ResizeDecision{PodUID: "pod-1", Verdict: ResizeVerdictDeferred})
+	}
+	assert.Len(t, l.forPod("pod-1"), 2)
+	assert.Empty(t, l.forPod("pod-2"))
+
+	l.removePod("pod-1")
+	assert.Empty(t, l.forPod("pod-1"))
+}
+
+func TestResizeDecisionString(t *testing.T) {
+	d := ResizeDecision{
+		PodUID:  "pod-1",
+		Verdict: ResizeVerdictInfeasible,
+		Fits: []ResourceFit{{
+			Resource: v1.ResourceMemory,
+			Delta:    resource.MustParse("1500m"),
+			Headroom: resource.MustParse("800m"),
+			Short:    resource.MustParse("700m"),
+		}},
+	}
+	require.Contains(t, d.String(), "short=700m")
+	require.Contains(t, d.String(), fmt.Sprintf("verdict=%s", ResizeVerdictInfeasible))
+}
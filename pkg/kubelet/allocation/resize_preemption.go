@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+// This file adds the logic a real RetryPendingResizes would need to
+// resolve a Deferred resize that node-level shortfall alone would leave
+// stuck forever: when the highest-priority pending resize (per
+// sortPendingResizes's ordering) still doesn't fit, selectVictims picks
+// pods to downsize or evict from the candidates it is given, least
+// disruptive first, until they free enough room to admit it. A real integration would have the manager call
+// statusManager.SetPodResizePreempting for every selected victim.
+// recordPreemptionDecision files the outcome (did the selected victims
+// free enough headroom, or is the pod still short) in the same
+// resizeDecisionLog other admission paths use, so a resize that only
+// succeeded because of preemption would be traceable the same way.
+//
+// manager.go, RetryPendingResizes, and the status package aren't part of
+// this checkout, so there is no real caller of selectVictims here and no
+// PodResizePreempting condition is ever set; this file is exercised
+// directly by this package's own tests.
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// ResizePreemptionPolicy controls whether and how the allocation manager
+// may disrupt other pods to admit a higher-priority Deferred resize.
+type ResizePreemptionPolicy string
+
+const (
+	// ResizePreemptionNever never preempts; a Deferred resize simply
+	// waits out its backoff (and eventually its TTL) like today.
+	ResizePreemptionNever ResizePreemptionPolicy = "Never"
+	// ResizePreemptionPreferLowerPriority downsizes lower-priority pods
+	// that have actuated-but-unused headroom, but never evicts.
+	ResizePreemptionPreferLowerPriority ResizePreemptionPolicy = "PreferLowerPriority"
+	// ResizePreemptionEvictIfNeeded additionally evicts a victim (via the
+	// eviction manager) if downsizing it wouldn't free enough room.
+	ResizePreemptionEvictIfNeeded ResizePreemptionPolicy = "EvictIfNeeded"
+)
+
+// preemptionCandidate is one pod the scorer considered as a victim.
+type preemptionCandidate struct {
+	PodUID     types.UID
+	Priority   int32
+	QOSClass   v1.PodQOSClass
+	Headroom   resource.Quantity // actuated-but-unused amount of the short resource
+	WouldEvict bool              // true if downsizing alone can't free enough, so eviction is required
+}
+
+// victimScore orders candidates so that the least disruptive option sorts
+// first: prefer downsizing over evicting, then lower PriorityClass, then
+// break ties on QoS (BestEffort > Burstable > Guaranteed, i.e. BestEffort
+// pods are preempted before Guaranteed ones).
+func victimScore(c preemptionCandidate) (evicts bool, priority int32, qos int) {
+	return c.WouldEvict, c.Priority, qosRank(c.QOSClass)
+}
+
+func qosRank(qos v1.PodQOSClass) int {
+	switch qos {
+	case v1.PodQOSBestEffort:
+		return 0
+	case v1.PodQOSBurstable:
+		return 1
+	default: // Guaranteed
+		return 2
+	}
+}
+
+// selectVictims picks candidates, in increasing order of disruption, until
+// their combined headroom for the named resource meets or exceeds
+// shortfall. It returns nil if policy is ResizePreemptionNever, or if the
+// candidates the policy allows (only those with WouldEvict false, unless
+// policy is ResizePreemptionEvictIfNeeded) can't together free enough room.
+func selectVictims(policy ResizePreemptionPolicy, resourceName v1.ResourceName, shortfall resource.Quantity, candidates []preemptionCandidate) []preemptionCandidate {
+	if policy == ResizePreemptionNever || len(candidates) == 0 {
+		return nil
+	}
+
+	sorted := append([]preemptionCandidate(nil), candidates...)
+	sortCandidates(sorted)
+
+	var selected []preemptionCandidate
+	freed := resource.Quantity{}
+	for _, c := range sorted {
+		if c.WouldEvict && policy != ResizePreemptionEvictIfNeeded {
+			continue
+		}
+		selected = append(selected, c)
+		freed.Add(c.Headroom)
+		if freed.Cmp(shortfall) >= 0 {
+			return selected
+		}
+	}
+	return nil
+}
+
+func sortCandidates(candidates []preemptionCandidate) {
+	// Simple insertion sort: the candidate lists involved here are the
+	// handful of pods pending on one node, not a cluster-wide list.
+	for i := 1; i < len(candidates); i++ {
+		for j := i; j > 0 && less(candidates[j], candidates[j-1]); j-- {
+			candidates[j], candidates[j-1] = candidates[j-1], candidates[j]
+		}
+	}
+}
+
+func less(a, b preemptionCandidate) bool {
+	aEvicts, aPriority, aQOS := victimScore(a)
+	bEvicts, bPriority, bQOS := victimScore(b)
+	if aEvicts != bEvicts {
+		return !aEvicts // prefer downsizing (false) over evicting (true)
+	}
+	if aPriority != bPriority {
+		return aPriority < bPriority
+	}
+	return aQOS < bQOS
+}
+
+// recordPreemptionDecision builds the ResizeDecision for one selectVictims
+// outcome and files it in log, so a pending resize that only got admitted
+// (or stayed Deferred) because of preemption shows up the same way any
+// other admission attempt does on /debug/resize: the Fits entry's Short
+// field is non-zero exactly when selected didn't free enough headroom to
+// cover shortfall on its own.
+func recordPreemptionDecision(log *resizeDecisionLog, podUID types.UID, resourceName v1.ResourceName, shortfall resource.Quantity, selected []preemptionCandidate) ResizeDecision {
+	freed := resource.Quantity{}
+	for _, c := range selected {
+		freed.Add(c.Headroom)
+	}
+
+	short := shortfall.DeepCopy()
+	short.Sub(freed)
+	if short.Sign() < 0 {
+		short.Set(0)
+	}
+
+	verdict := ResizeVerdictDeferred
+	if short.Sign() == 0 {
+		verdict = ResizeVerdictAdmit
+	}
+
+	decision := ResizeDecision{
+		PodUID:  podUID,
+		Verdict: verdict,
+		Fits: []ResourceFit{{
+			Resource: resourceName,
+			Delta:    shortfall,
+			Headroom: freed,
+			Short:    short,
+		}},
+	}
+	log.record(klog.Background(), decision)
+	return decision
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestSelectVictimsPrefersDownsizeOverEviction(t *testing.T) {
+	candidates := []preemptionCandidate{
+		{PodUID: "evict-me", Priority: 0, QOSClass: v1.PodQOSBurstable, Headroom: resource.MustParse("2"), WouldEvict: true},
+		{PodUID: "downsize-me", Priority: 0, QOSClass: v1.PodQOSBurstable, Headroom: resource.MustParse("2"), WouldEvict: false},
+	}
+
+	victims := selectVictims(ResizePreemptionPreferLowerPriority, v1.ResourceCPU, resource.MustParse("1"), candidates)
+	assert.Len(t, victims, 1)
+	assert.Equal(t, "downsize-me", string(victims[0].PodUID))
+}
+
+func TestSelectVictimsNeverPolicyReturnsNil(t *testing.T) {
+	candidates := []preemptionCandidate{{PodUID: "pod-1", Headroom: resource.MustParse("4")}}
+	assert.Nil(t, selectVictims(ResizePreemptionNever, v1.ResourceCPU, resource.MustParse("1"), candidates))
+}
+
+func TestSelectVictimsFallsBackToEvictionWhenAllowed(t *testing.T) {
+	candidates := []preemptionCandidate{
+		{PodUID: "must-evict", Priority: 0, QOSClass: v1.PodQOSBurstable, Headroom: resource.MustParse("3"), WouldEvict: true},
+	}
+	assert.Nil(t, selectVictims(ResizePreemptionPreferLowerPriority, v1.ResourceCPU, resource.MustParse("1"), candidates))
+
+	victims := selectVictims(ResizePreemptionEvictIfNeeded, v1.ResourceCPU, resource.MustParse("1"), candidates)
+	assert.Len(t, victims, 1)
+}
+
+func TestSelectVictimsOrdersByPriorityThenQOS(t *testing.T) {
+	candidates := []preemptionCandidate{
+		{PodUID: "guaranteed-low-priority", Priority: 0, QOSClass: v1.PodQOSGuaranteed, Headroom: resource.MustParse("1")},
+		{PodUID: "besteffort-low-priority", Priority: 0, QOSClass: v1.PodQOSBestEffort, Headroom: resource.MustParse("1")},
+		{PodUID: "burstable-high-priority", Priority: 100, QOSClass: v1.PodQOSBurstable, Headroom: resource.MustParse("1")},
+	}
+
+	victims := selectVictims(ResizePreemptionPreferLowerPriority, v1.ResourceCPU, resource.MustParse("1"), candidates)
+	assert.Equal(t, "besteffort-low-priority", string(victims[0].PodUID))
+}
+
+func TestRecordPreemptionDecisionAdmitsWhenVictimsCoverShortfall(t *testing.T) {
+	log := newResizeDecisionLog(1)
+	selected := []preemptionCandidate{{PodUID: "victim-1", Headroom: resource.MustParse("2")}}
+
+	decision := recordPreemptionDecision(log, "pod-1", v1.ResourceCPU, resource.MustParse("2"), selected)
+
+	assert.Equal(t, ResizeVerdictAdmit, decision.Verdict)
+	require.Len(t, decision.Fits, 1)
+	assert.Equal(t, int64(0), decision.Fits[0].Short.MilliValue())
+	assert.Equal(t, []ResizeDecision{decision}, log.forPod("pod-1"))
+}
+
+func TestRecordPreemptionDecisionStaysDeferredWhenVictimsFallShort(t *testing.T) {
+	log := newResizeDecisionLog(1)
+	selected := []preemptionCandidate{{PodUID: "victim-1", Headroom: resource.MustParse("1")}}
+
+	decision := recordPreemptionDecision(log, "pod-1", v1.ResourceCPU, resource.MustParse("2"), selected)
+
+	assert.Equal(t, ResizeVerdictDeferred, decision.Verdict)
+	require.Len(t, decision.Fits, 1)
+	assert.Equal(t, resource.MustParse("1").MilliValue(), decision.Fits[0].Short.MilliValue())
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+// This file adds a "shared-exclusive" CPU allocation mode: a Guaranteed
+// pod that opts in via SharedPoolCPUSetAnnotationKey still gets a pinned
+// cpuset, like the static CPU manager gives today, but that cpuset is
+// drawn from a configurable shared pool and may overlap with burstable
+// workloads running on the same cores. A real integration would have
+// manager.SetActuatedResources/GetActuatedResources extend their
+// state.PodResourceInfoMap tracking of actuated (pod, container) resource
+// quantities with a cpuset assignment, and let HandlePodResourcesResize
+// rewrite a running container's cgroup cpuset.cpus in place instead of
+// restarting it. numaAssignmentForCPUSet derives the
+// topology_feasibility.go numaAssignment view of a shared-pool cpuset, so
+// checkTopologyFit could be run against a shared-exclusive container the
+// same way it already runs against the static CPU manager's
+// fully-exclusive one.
+//
+// manager.go and state.PodResourceInfoMap aren't part of this checkout, so
+// there is no real caller of sharedCPUPool here and no cgroup write
+// happens anywhere in this diff; this file is exercised directly by this
+// package's own tests.
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/cpuset"
+)
+
+// SharedPoolCPUSetAnnotationKey opts a Guaranteed pod's integer-CPU
+// containers into the shared-exclusive allocation mode instead of the
+// static CPU manager's fully-exclusive cpuset.
+const SharedPoolCPUSetAnnotationKey = "cpuset.kubernetes.io/shared-exclusive"
+
+// sharedCPUPool tracks which cores are available to shared-exclusive
+// containers and what's currently assigned to each (pod, container), so
+// that a resize can recompute and reissue a cpuset without restarting the
+// container.
+type sharedCPUPool struct {
+	mu       sync.Mutex
+	pool     cpuset.CPUSet
+	assigned map[types.UID]map[string]cpuset.CPUSet
+}
+
+func newSharedCPUPool(pool cpuset.CPUSet) *sharedCPUPool {
+	return &sharedCPUPool{pool: pool, assigned: make(map[types.UID]map[string]cpuset.CPUSet)}
+}
+
+// usedCPUs is the union of every currently assigned cpuset, i.e. the
+// cores that are committed to shared-exclusive containers and therefore
+// count against the pool's remaining capacity.
+func (s *sharedCPUPool) usedCPUs() cpuset.CPUSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	used := cpuset.New()
+	for _, byContainer := range s.assigned {
+		for _, cs := range byContainer {
+			used = used.Union(cs)
+		}
+	}
+	return used
+}
+
+// feasible reports whether numCPUs additional cores can be drawn from the
+// pool right now, accounting for what's already assigned. It mirrors the
+// Deferred/Infeasible admission checks the manager already does for plain
+// CPU/memory requests, but against the pool's remaining capacity instead
+// of raw node allocatable.
+func (s *sharedCPUPool) feasible(numCPUs int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	used := 0
+	for _, byContainer := range s.assigned {
+		for _, cs := range byContainer {
+			used += cs.Size()
+		}
+	}
+	return s.pool.Size()-used >= numCPUs
+}
+
+// assign draws numCPUs cores from the pool for (podUID, containerName),
+// releasing any cpuset previously assigned to that container first so a
+// resize can grow or shrink the assignment in place. It does not itself
+// touch the container's cgroup; the caller is responsible for writing the
+// returned cpuset to cpuset.cpus.
+func (s *sharedCPUPool) assign(podUID types.UID, containerName string, numCPUs int) (cpuset.CPUSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if byContainer, ok := s.assigned[podUID]; ok {
+		delete(byContainer, containerName)
+	}
+
+	used := cpuset.New()
+	for _, byContainer := range s.assigned {
+		for _, cs := range byContainer {
+			used = used.Union(cs)
+		}
+	}
+	available := s.pool.Difference(used)
+	if available.Size() < numCPUs {
+		return cpuset.CPUSet{}, fmt.Errorf("shared CPU pool has %d cores free, need %d", available.Size(), numCPUs)
+	}
+
+	assignment := cpuset.New(available.List()[:numCPUs]...)
+	byContainer, ok := s.assigned[podUID]
+	if !ok {
+		byContainer = make(map[string]cpuset.CPUSet)
+		s.assigned[podUID] = byContainer
+	}
+	byContainer[containerName] = assignment
+	return assignment, nil
+}
+
+func (s *sharedCPUPool) release(podUID types.UID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.assigned, podUID)
+}
+
+func (s *sharedCPUPool) get(podUID types.UID, containerName string) (cpuset.CPUSet, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byContainer, ok := s.assigned[podUID]
+	if !ok {
+		return cpuset.CPUSet{}, false
+	}
+	cs, ok := byContainer[containerName]
+	return cs, ok
+}
+
+// numaAssignmentForCPUSet groups a container's shared-pool cpuset by NUMA
+// node via nodeOfCPU, producing the []numaAssignment checkTopologyFit
+// expects. Memory is left zero: the shared CPU pool doesn't pin memory,
+// only CPUs.
+func (s *sharedCPUPool) numaAssignmentForCPUSet(podUID types.UID, containerName string, nodeOfCPU func(cpu int) int) []numaAssignment {
+	cs, ok := s.get(podUID, containerName)
+	if !ok {
+		return nil
+	}
+
+	byNode := map[int]int{}
+	for _, cpu := range cs.List() {
+		byNode[nodeOfCPU(cpu)]++
+	}
+
+	assignments := make([]numaAssignment, 0, len(byNode))
+	for node, count := range byNode {
+		assignments = append(assignments, numaAssignment{
+			NUMANodeID: node,
+			CPUs:       *resource.NewQuantity(int64(count), resource.DecimalSI),
+		})
+	}
+	sortNUMAAssignments(assignments)
+	return assignments
+}
+
+func sortNUMAAssignments(assignments []numaAssignment) {
+	for i := 1; i < len(assignments); i++ {
+		for j := i; j > 0 && assignments[j].NUMANodeID < assignments[j-1].NUMANodeID; j-- {
+			assignments[j], assignments[j-1] = assignments[j-1], assignments[j]
+		}
+	}
+}
+
+// wantsSharedExclusiveCPUSet reports whether the pod opted into the
+// shared-exclusive allocation mode. It's only meaningful for Guaranteed
+// pods with integer CPU requests; callers are expected to have already
+// checked QoS class.
+func wantsSharedExclusiveCPUSet(pod *v1.Pod) bool {
+	return pod.Annotations[SharedPoolCPUSetAnnotationKey] == "true"
+}
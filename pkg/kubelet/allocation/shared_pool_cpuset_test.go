@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/cpuset"
+)
+
+func TestSharedCPUPoolAssignAndResize(t *testing.T) {
+	pool := newSharedCPUPool(cpuset.New(0, 1, 2, 3))
+
+	assigned, err := pool.assign("pod-1", "c1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, assigned.Size())
+	assert.True(t, pool.feasible(2))
+	assert.False(t, pool.feasible(3))
+
+	// Resizing down to 1 CPU should release the previous assignment
+	// instead of stacking on top of it.
+	resized, err := pool.assign("pod-1", "c1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resized.Size())
+	assert.True(t, pool.feasible(3))
+
+	_, err = pool.assign("pod-2", "c1", 5)
+	assert.Error(t, err)
+
+	pool.release("pod-1")
+	assert.True(t, pool.feasible(4))
+}
+
+func TestNUMAAssignmentForCPUSetGroupsByNode(t *testing.T) {
+	pool := newSharedCPUPool(cpuset.New(0, 1, 2, 3))
+	_, err := pool.assign("pod-1", "c1", 3)
+	require.NoError(t, err)
+
+	// CPUs 0,1 on node 0; CPU 2 on node 1.
+	nodeOfCPU := func(cpu int) int {
+		if cpu < 2 {
+			return 0
+		}
+		return 1
+	}
+
+	assignments := pool.numaAssignmentForCPUSet("pod-1", "c1", nodeOfCPU)
+	require.Len(t, assignments, 2)
+	assert.Equal(t, 0, assignments[0].NUMANodeID)
+	assert.Equal(t, int64(2), assignments[0].CPUs.Value())
+	assert.Equal(t, 1, assignments[1].NUMANodeID)
+	assert.Equal(t, int64(1), assignments[1].CPUs.Value())
+}
+
+func TestNUMAAssignmentForCPUSetUnassignedContainerIsNil(t *testing.T) {
+	pool := newSharedCPUPool(cpuset.New(0, 1))
+	assert.Nil(t, pool.numaAssignmentForCPUSet("pod-1", "c1", func(int) int { return 0 }))
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+// This file adds startup-CPU-boost support: a pod may ask to start with
+// elevated resources that the allocation manager automatically downshifts
+// back to the declared spec once a deadline or pod condition is reached.
+// A real integration would have manager.HandlePodResourcesResize record the
+// boosted values as the initial allocation (so they apply before the pod's
+// first start) and call startupBoostTracker.record, with the manager's
+// periodic reconcile tick calling startupBoostTracker.expired and pushing a
+// revert resize through PushPendingResize/RetryPendingResizes, exactly as
+// if the user had edited the pod spec themselves. applyExpiredBoosts drives
+// that revert through the same deferredResizeQueue a plain Deferred resize
+// uses, so a revert that can't be admitted immediately (e.g. a sibling
+// container grew into the about-to-be-freed headroom first) retries on the
+// normal backoff/TTL schedule instead of being silently dropped or
+// re-attempted on every tick.
+//
+// manager.go and the rest of the kubelet packages that would drive that
+// reconcile tick aren't part of this checkout, so there is no real caller
+// of any of the above here; startupBoostTracker/applyExpiredBoosts are
+// exercised directly by this package's own tests.
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// StartupBoostAnnotationKey lets a pod request elevated initial resources
+// that the allocation manager automatically downshifts back to the pod's
+// declared spec once the boost expires. Example value:
+//
+//	{"cpu": "2", "memory": "1Gi", "until": "PodReady"}
+//
+// "until" is either a Go duration string (e.g. "120s") or the name of a
+// pod condition type that must become True. "PodReady" (the most common
+// value) stands for the pod's Ready condition, whose type is "Ready".
+const StartupBoostAnnotationKey = "resize.kubernetes.io/startup-boost"
+
+// StartupBoostRevertDeferred is the reason recorded against
+// deferredResizeQueue while a startup-boost revert can't yet be admitted.
+const StartupBoostRevertDeferred = "StartupBoostRevertDeferred"
+
+// startupBoostSpec is the parsed form of the StartupBoostAnnotationKey
+// annotation value.
+type startupBoostSpec struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+	Until  string `json:"until"`
+}
+
+// startupBoost tracks one in-flight boost: the container it was applied
+// to, the resources it should revert to, and the condition under which it
+// expires.
+type startupBoost struct {
+	podUID        types.UID
+	containerName string
+	revertTo      v1.ResourceRequirements
+	deadline      time.Time           // zero if Until names a pod condition instead
+	conditionType v1.PodConditionType // empty if Until is a duration
+}
+
+// startupBoostTracker records active startup boosts in memory so that
+// expired can report which of them are due for a revert. It only
+// remembers what to revert to and when; it does not persist anything, so
+// its contents do not survive a kubelet restart.
+type startupBoostTracker struct {
+	mu     sync.Mutex
+	active map[types.UID]map[string]*startupBoost // podUID -> container name -> boost
+}
+
+func newStartupBoostTracker() *startupBoostTracker {
+	return &startupBoostTracker{active: make(map[types.UID]map[string]*startupBoost)}
+}
+
+// parseStartupBoost reads the pod's StartupBoostAnnotationKey annotation,
+// if present, and returns the resources the allocation manager should use
+// as the container's initial allocation plus a startupBoost describing
+// how and when to revert it. ok is false when the pod doesn't request a
+// boost for this container, or the annotation is malformed (in which case
+// the boost is simply skipped rather than failing admission).
+func parseStartupBoost(pod *v1.Pod, container *v1.Container) (v1.ResourceRequirements, *startupBoost, bool) {
+	raw, found := pod.Annotations[StartupBoostAnnotationKey]
+	if !found {
+		return v1.ResourceRequirements{}, nil, false
+	}
+
+	var spec startupBoostSpec
+	if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+		klog.Background().Error(err, "Ignoring malformed startup-boost annotation", "pod", klog.KObj(pod), "container", container.Name)
+		return v1.ResourceRequirements{}, nil, false
+	}
+
+	boosted := *container.Resources.DeepCopy()
+	if boosted.Requests == nil {
+		boosted.Requests = v1.ResourceList{}
+	}
+	if boosted.Limits == nil {
+		boosted.Limits = v1.ResourceList{}
+	}
+	if spec.CPU != "" {
+		q, err := resourceQuantity(spec.CPU)
+		if err != nil {
+			return v1.ResourceRequirements{}, nil, false
+		}
+		boosted.Requests[v1.ResourceCPU] = q
+		boosted.Limits[v1.ResourceCPU] = q
+	}
+	if spec.Memory != "" {
+		q, err := resourceQuantity(spec.Memory)
+		if err != nil {
+			return v1.ResourceRequirements{}, nil, false
+		}
+		boosted.Requests[v1.ResourceMemory] = q
+		boosted.Limits[v1.ResourceMemory] = q
+	}
+
+	boost := &startupBoost{
+		podUID:        pod.UID,
+		containerName: container.Name,
+		revertTo:      *container.Resources.DeepCopy(),
+	}
+	if d, err := time.ParseDuration(spec.Until); err == nil {
+		boost.deadline = time.Now().Add(d)
+	} else if spec.Until == "PodReady" {
+		boost.conditionType = v1.PodReady
+	} else {
+		boost.conditionType = v1.PodConditionType(spec.Until)
+	}
+
+	return boosted, boost, true
+}
+
+func (t *startupBoostTracker) record(boost *startupBoost) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	byContainer, ok := t.active[boost.podUID]
+	if !ok {
+		byContainer = make(map[string]*startupBoost)
+		t.active[boost.podUID] = byContainer
+	}
+	byContainer[boost.containerName] = boost
+}
+
+func (t *startupBoostTracker) removePod(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.active, uid)
+}
+
+// expired returns the boosts that should be reverted now, given the
+// current pod status (used to check condition-based expiry).
+func (t *startupBoostTracker) expired(getPodStatus func(types.UID) (*v1.PodStatus, bool)) []*startupBoost {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	var due []*startupBoost
+	for uid, byContainer := range t.active {
+		status, ok := getPodStatus(uid)
+		for name, boost := range byContainer {
+			switch {
+			case !boost.deadline.IsZero() && now.After(boost.deadline):
+				due = append(due, boost)
+				delete(byContainer, name)
+			case boost.conditionType != "" && ok && podConditionTrue(status, boost.conditionType):
+				due = append(due, boost)
+				delete(byContainer, name)
+			}
+		}
+		if len(byContainer) == 0 {
+			delete(t.active, uid)
+		}
+	}
+	return due
+}
+
+// applyExpiredBoosts reverts each expired boost's container back to its
+// declared resources via admit, clearing the pod's deferred-resize history
+// on success. A revert that admit rejects is recorded in queue as Deferred
+// and returned in deferred, so it follows the same backoff/TTL
+// deferredResizeQueue applies to any other Deferred resize. Once that TTL
+// is exceeded the revert is given up on: its queue history is cleared and
+// it is returned in admitted as well, although admit never accepted it.
+func applyExpiredBoosts(queue *deferredResizeQueue, now time.Time, boosts []*startupBoost, admit func(*startupBoost) (ok bool, reason string)) (admitted, deferred []*startupBoost) {
+	for _, boost := range boosts {
+		if ok, _ := admit(boost); ok {
+			queue.clear(boost.podUID)
+			admitted = append(admitted, boost)
+			continue
+		}
+
+		_, _, _, timedOut := queue.recordDeferred(boost.podUID, now, StartupBoostRevertDeferred)
+		if timedOut {
+			// The revert itself is stuck; give up reverting rather than
+			// retrying forever, same as any other timed-out Deferred
+			// resize.
+			queue.clear(boost.podUID)
+			admitted = append(admitted, boost)
+			continue
+		}
+		deferred = append(deferred, boost)
+	}
+	return admitted, deferred
+}
+
+func podConditionTrue(status *v1.PodStatus, conditionType v1.PodConditionType) bool {
+	for _, c := range status.Conditions {
+		if c.Type == conditionType {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func resourceQuantity(s string) (resource.Quantity, error) {
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("parsing quantity %q: %w", s, err)
+	}
+	return q, nil
+}
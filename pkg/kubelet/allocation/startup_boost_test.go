@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseStartupBoost(t *testing.T) {
+	container := &v1.Container{
+		Name: "c1",
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("200m")},
+		},
+	}
+
+	t.Run("no annotation", func(t *testing.T) {
+		pod := &v1.Pod{}
+		_, _, ok := parseStartupBoost(pod, container)
+		assert.False(t, ok)
+	})
+
+	t.Run("duration based boost", func(t *testing.T) {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					StartupBoostAnnotationKey: `{"cpu":"2","until":"60s"}`,
+				},
+			},
+		}
+		boosted, boost, ok := parseStartupBoost(pod, container)
+		require.True(t, ok)
+		assert.Equal(t, resource.MustParse("2"), boosted.Requests[v1.ResourceCPU])
+		assert.Equal(t, resource.MustParse("200m"), boost.revertTo.Requests[v1.ResourceCPU])
+		assert.WithinDuration(t, time.Now().Add(60*time.Second), boost.deadline, 2*time.Second)
+	})
+
+	t.Run("condition based boost", func(t *testing.T) {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					StartupBoostAnnotationKey: `{"memory":"1Gi","until":"PodReady"}`,
+				},
+			},
+		}
+		boosted, boost, ok := parseStartupBoost(pod, container)
+		require.True(t, ok)
+		assert.Equal(t, resource.MustParse("1Gi"), boosted.Requests[v1.ResourceMemory])
+		assert.Equal(t, v1.PodReady, boost.conditionType)
+		assert.True(t, boost.deadline.IsZero())
+	})
+
+	t.Run("malformed annotation is ignored", func(t *testing.T) {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{StartupBoostAnnotationKey: `not-json`},
+			},
+		}
+		_, _, ok := parseStartupBoost(pod, container)
+		assert.False(t, ok)
+	})
+}
+
+func TestStartupBoostTrackerExpiry(t *testing.T) {
+	tracker := newStartupBoostTracker()
+	tracker.record(&startupBoost{podUID: "pod-1", containerName: "c1", deadline: time.Now().Add(-time.Second)})
+	tracker.record(&startupBoost{podUID: "pod-2", containerName: "c1", conditionType: v1.PodReady})
+	tracker.record(&startupBoost{podUID: "pod-3", containerName: "c1", deadline: time.Now().Add(time.Hour)})
+
+	statuses := map[types.UID]*v1.PodStatus{
+		"pod-2": {Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}},
+	}
+	due := tracker.expired(func(uid types.UID) (*v1.PodStatus, bool) {
+		status, ok := statuses[uid]
+		return status, ok
+	})
+	require.Len(t, due, 2)
+}
+
+func TestApplyExpiredBoostsClearsQueueOnAdmit(t *testing.T) {
+	queue := newDeferredResizeQueue(deferredResizeBackoff{})
+	queue.recordDeferred("pod-1", time.Now(), "SomeOtherReason")
+	boosts := []*startupBoost{{podUID: "pod-1", containerName: "c1"}}
+
+	admitted, deferred := applyExpiredBoosts(queue, time.Now(), boosts, func(*startupBoost) (bool, string) {
+		return true, ""
+	})
+
+	assert.Len(t, admitted, 1)
+	assert.Empty(t, deferred)
+	assert.Equal(t, []types.UID{"pod-1"}, queue.due(time.Now(), []types.UID{"pod-1"}))
+}
+
+func TestApplyExpiredBoostsRetriesThroughDeferredQueueOnRejection(t *testing.T) {
+	queue := newDeferredResizeQueue(deferredResizeBackoff{})
+	boosts := []*startupBoost{{podUID: "pod-1", containerName: "c1"}}
+
+	admitted, deferred := applyExpiredBoosts(queue, time.Now(), boosts, func(*startupBoost) (bool, string) {
+		return false, "sibling grew into the headroom first"
+	})
+
+	assert.Empty(t, admitted)
+	require.Len(t, deferred, 1)
+	assert.Equal(t, types.UID("pod-1"), deferred[0].podUID)
+}
+
+func TestApplyExpiredBoostsGivesUpAfterDeferredTTL(t *testing.T) {
+	queue := newDeferredResizeQueue(deferredResizeBackoff{TTL: time.Millisecond})
+	boosts := []*startupBoost{{podUID: "pod-1", containerName: "c1"}}
+	reject := func(*startupBoost) (bool, string) { return false, "still short" }
+
+	applyExpiredBoosts(queue, time.Now(), boosts, reject)
+	admitted, deferred := applyExpiredBoosts(queue, time.Now().Add(time.Hour), boosts, reject)
+
+	assert.Empty(t, deferred)
+	require.Len(t, admitted, 1, "a revert that never fits should eventually be given up on, not retried forever")
+}
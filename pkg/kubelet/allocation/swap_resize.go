@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+// This file models what would replace the blanket "swap is not supported"
+// Infeasible result CheckPodResizeInProgress used to return for every
+// LimitedSwap container. A LimitedSwap container's swap limit is derived
+// from its memory request, so growing or shrinking that request has to
+// recompute memory.swap.max the same way kuberuntime does at
+// container-create time, and push it to the runtime alongside the memory
+// limit change. A real integration would have NewInMemoryManager take a
+// swapCapacityProvider so this package doesn't need to import the
+// cadvisor/cm machine-info types directly.
+//
+// manager.go and CheckPodResizeInProgress aren't part of this checkout, so
+// resizeLimitedSwap/ResizeSwap have no real caller: the production resize
+// path still returns the old Infeasible result for a LimitedSwap container
+// whose memory ResizePolicy is NotRequired, as allocation_manager_test.go's
+// LimitedSwap NotRequired case continues to assert.
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+// SwapLimitUnsupported is used as the Infeasible reason when a LimitedSwap
+// container's resize can't be actuated because the CRI runtime rejects the
+// in-place swap limit update. A memory ResizePolicy of RestartContainer
+// never yields it: the restart applies the new swap limit instead.
+const SwapLimitUnsupported = "SwapLimitUnsupported"
+
+// swapCapacityProvider reports the node's total swap capacity in bytes, the
+// same value the memory/cpu manager already reads off cadvisor's MachineInfo
+// at startup. It's threaded through rather than recomputed here so this
+// package never needs to know how swap capacity is discovered.
+type swapCapacityProvider func() int64
+
+// swapRuntime is the subset of the container runtime interaction a resize
+// needs to actuate a LimitedSwap limit change without a restart.
+type swapRuntime interface {
+	// ResizeSwap pushes a new memory.swap.max value for containerID. An
+	// error return means the runtime could not apply it; the caller
+	// falls back to Infeasible with SwapLimitUnsupported.
+	ResizeSwap(containerID kubecontainer.ContainerID, newSwapLimit int64) error
+}
+
+// limitedSwapLimit applies the existing LimitedSwap formula -
+// containerMemoryRequest / nodeMemoryCapacity * nodeSwapCapacity - to
+// compute the swap.max a container should have after a resize. It mirrors
+// kuberuntime's at-create-time calculation so a resize never leaves a
+// container with a different swap ceiling than if it had started at the
+// new request.
+func limitedSwapLimit(containerMemoryRequest resource.Quantity, nodeMemoryCapacity, nodeSwapCapacity int64) int64 {
+	if nodeMemoryCapacity <= 0 {
+		return 0
+	}
+	memRequest := containerMemoryRequest.Value()
+	return memRequest * nodeSwapCapacity / nodeMemoryCapacity
+}
+
+// resizeLimitedSwap recomputes a LimitedSwap container's swap limit for its
+// new memory request and pushes it through runtime.ResizeSwap. It returns
+// (false, reason) instead of an error when the resize can't be actuated in
+// place, so the caller can fold that into the same Infeasible path it uses
+// for other un-actuatable resizes.
+func resizeLimitedSwap(runtime swapRuntime, containerID kubecontainer.ContainerID, resizePolicy []v1.ContainerResizePolicy, newMemoryRequest resource.Quantity, nodeMemoryCapacity int64, swapCapacity swapCapacityProvider) (ok bool, reason string) {
+	for _, p := range resizePolicy {
+		if p.ResourceName == v1.ResourceMemory && p.RestartPolicy != v1.NotRequired {
+			// RestartContainer already restarts the container, which
+			// picks up the new swap limit at create time; nothing to
+			// actuate in place here.
+			return true, ""
+		}
+	}
+
+	newLimit := limitedSwapLimit(newMemoryRequest, nodeMemoryCapacity, swapCapacity())
+	if err := runtime.ResizeSwap(containerID, newLimit); err != nil {
+		return false, fmt.Sprintf("%s: %v", SwapLimitUnsupported, err)
+	}
+	return true, ""
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+func TestLimitedSwapLimit(t *testing.T) {
+	// 500Mi request / 4Gi node memory * 2Gi node swap.
+	limit := limitedSwapLimit(resource.MustParse("500Mi"), 4*1024*1024*1024, 2*1024*1024*1024)
+	assert.Equal(t, int64(500*1024*1024/2), limit)
+}
+
+func TestLimitedSwapLimitNoMemoryCapacity(t *testing.T) {
+	assert.Equal(t, int64(0), limitedSwapLimit(resource.MustParse("500Mi"), 0, 2*1024*1024*1024))
+}
+
+type fakeSwapRuntime struct {
+	err error
+}
+
+func (f fakeSwapRuntime) ResizeSwap(containerID kubecontainer.ContainerID, newSwapLimit int64) error {
+	return f.err
+}
+
+func TestResizeLimitedSwapRestartPolicySkipsActuation(t *testing.T) {
+	ok, reason := resizeLimitedSwap(fakeSwapRuntime{}, kubecontainer.ContainerID{ID: "c1"},
+		[]v1.ContainerResizePolicy{{ResourceName: v1.ResourceMemory, RestartPolicy: v1.RestartContainer}},
+		resource.MustParse("500Mi"), 4*1024*1024*1024, func() int64 { return 2 * 1024 * 1024 * 1024 })
+	require.True(t, ok)
+	assert.Empty(t, reason)
+}
+
+func TestResizeLimitedSwapPushesNewLimit(t *testing.T) {
+	ok, reason := resizeLimitedSwap(fakeSwapRuntime{}, kubecontainer.ContainerID{ID: "c1"},
+		[]v1.ContainerResizePolicy{{ResourceName: v1.ResourceMemory, RestartPolicy: v1.NotRequired}},
+		resource.MustParse("500Mi"), 4*1024*1024*1024, func() int64 { return 2 * 1024 * 1024 * 1024 })
+	require.True(t, ok)
+	assert.Empty(t, reason)
+}
+
+func TestResizeLimitedSwapFallsBackToInfeasibleWhenRuntimeRejects(t *testing.T) {
+	runtime := fakeSwapRuntime{err: fmt.Errorf("swap limit updates unsupported")}
+	ok, reason := resizeLimitedSwap(runtime, kubecontainer.ContainerID{ID: "c1"},
+		[]v1.ContainerResizePolicy{{ResourceName: v1.ResourceMemory, RestartPolicy: v1.NotRequired}},
+		resource.MustParse("500Mi"), 4*1024*1024*1024, func() int64 { return 2 * 1024 * 1024 * 1024 })
+	require.False(t, ok)
+	assert.Contains(t, reason, SwapLimitUnsupported)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+// This file adds a topology-aware pre-check intended for
+// RetryPendingResizes/CheckPodResizeInProgress to run before falling back
+// to the plain aggregate-capacity check: a resize that fits the node as a
+// whole can still be infeasible on the pod's pinned socket/NUMA node once
+// cpumanager's static policy or memorymanager's single-NUMA-node policy is
+// involved. recordTopologyFit turns a checkTopologyFit outcome into a
+// ResizeDecision so a resize that fits the node but not the pod's NUMA
+// assignment is traceable the same way any other admission attempt is.
+//
+// manager.go and the rest of the kubelet packages that check/retry pod
+// resizes aren't part of this checkout, so there is no real caller here;
+// checkTopologyFit/recordTopologyFit are exercised directly by this
+// package's own tests, including the "whole-node fit fails because one
+// socket is saturated" case in topology_feasibility_test.go.
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// TopologyConstraintsNotSatisfiable is used when the delta can never
+	// be satisfied on the pod's currently assigned NUMA node(s) without
+	// breaking its topology hint (e.g. the hint is single-NUMA-node but
+	// the container is already spread over several nodes, so growing it
+	// at all would break the hint).
+	TopologyConstraintsNotSatisfiable = "TopologyConstraintsNotSatisfiable"
+	// TopologyResourcesExhausted is used when the delta could in
+	// principle be satisfied on the assigned node(s) without breaking
+	// the hint, but not right now because that specific node or socket
+	// is saturated; unlike TopologyConstraintsNotSatisfiable this is
+	// retried like any other Deferred resize.
+	TopologyResourcesExhausted = "TopologyResourcesExhausted"
+)
+
+// numaAssignment is the per-NUMA-node view cpumanager/memorymanager
+// already track for a container pinned by their static policies.
+type numaAssignment struct {
+	NUMANodeID int
+	CPUs       resource.Quantity
+	Memory     resource.Quantity
+}
+
+// topologyFit is the outcome of checking one resize delta against a
+// pod's existing NUMA assignment.
+type topologyFit int
+
+const (
+	topologyFitOK topologyFit = iota
+	topologyFitExhausted
+	topologyFitUnsatisfiable
+)
+
+// checkTopologyFit decides whether growing a container by (cpuDelta,
+// memDelta) can stay within its current NUMA assignment.
+// singleNUMANodePolicy mirrors memorymanager's single-NUMA-node policy:
+// when true, the delta must be satisfiable on exactly one of the assigned
+// nodes, never split across them.
+func checkTopologyFit(assignment []numaAssignment, cpuDelta, memDelta resource.Quantity, singleNUMANodePolicy bool, nodeFreeCPU, nodeFreeMemory func(numaNodeID int) resource.Quantity) topologyFit {
+	if len(assignment) == 0 {
+		// No pinned assignment (e.g. the container isn't on the static
+		// cpumanager/memorymanager policy); nothing to check here.
+		return topologyFitOK
+	}
+
+	if singleNUMANodePolicy && len(assignment) > 1 {
+		// The pod is already spread across multiple NUMA nodes, which
+		// single-NUMA-node would never have allowed for new requests;
+		// an existing spread assignment is left alone, but growing it
+		// further can't be satisfied without breaking the hint.
+		if cpuDelta.Sign() > 0 || memDelta.Sign() > 0 {
+			return topologyFitUnsatisfiable
+		}
+		return topologyFitOK
+	}
+
+	exhausted := false
+	for _, a := range assignment {
+		freeCPU, freeMemory := nodeFreeCPU(a.NUMANodeID), nodeFreeMemory(a.NUMANodeID)
+		if cpuDelta.Cmp(freeCPU) <= 0 && memDelta.Cmp(freeMemory) <= 0 {
+			return topologyFitOK
+		}
+		exhausted = true
+	}
+	if !singleNUMANodePolicy && len(assignment) > 1 {
+		// Without single-NUMA-node the delta may be split across the
+		// assigned nodes, so their combined free capacity counts.
+		totalCPU, totalMemory := resource.Quantity{}, resource.Quantity{}
+		for _, a := range assignment {
+			totalCPU.Add(nodeFreeCPU(a.NUMANodeID))
+			totalMemory.Add(nodeFreeMemory(a.NUMANodeID))
+		}
+		if cpuDelta.Cmp(totalCPU) <= 0 && memDelta.Cmp(totalMemory) <= 0 {
+			return topologyFitOK
+		}
+	}
+	if exhausted {
+		return topologyFitExhausted
+	}
+	return topologyFitUnsatisfiable
+}
+
+// topologyFitReason maps a topologyFit to the Infeasible/Deferred reason
+// the resize condition should carry.
+func topologyFitReason(fit topologyFit) (infeasible bool, reason string) {
+	switch fit {
+	case topologyFitUnsatisfiable:
+		return true, TopologyConstraintsNotSatisfiable
+	case topologyFitExhausted:
+		return false, TopologyResourcesExhausted
+	default:
+		return false, ""
+	}
+}
+
+// recordTopologyFit records a checkTopologyFit outcome as a ResizeDecision
+// for the CPU dimension (the resource most often pinned by cpumanager's
+// static policy): Infeasible when the hint can never be satisfied on the
+// pod's current NUMA assignment, Deferred when it's merely exhausted right
+// now, Admit otherwise.
+func recordTopologyFit(log *resizeDecisionLog, podUID types.UID, cpuDelta resource.Quantity, fit topologyFit) ResizeDecision {
+	decision := ResizeDecision{PodUID: podUID}
+	switch fit {
+	case topologyFitUnsatisfiable:
+		decision.Verdict = ResizeVerdictInfeasible
+		decision.LimitingResource = v1.ResourceCPU
+	case topologyFitExhausted:
+		decision.Verdict = ResizeVerdictDeferred
+		decision.LimitingResource = v1.ResourceCPU
+	default:
+		decision.Verdict = ResizeVerdictAdmit
+	}
+	decision.Fits = []ResourceFit{{Resource: v1.ResourceCPU, Delta: cpuDelta}}
+
+	log.record(klog.Background(), decision)
+	return decision
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestCheckTopologyFit(t *testing.T) {
+	assignment := []numaAssignment{{NUMANodeID: 0}}
+
+	freeCPU := func(int) resource.Quantity { return resource.MustParse("500m") }
+	freeMemory := func(int) resource.Quantity { return resource.MustParse("1Gi") }
+
+	t.Run("fits on the assigned node", func(t *testing.T) {
+		fit := checkTopologyFit(assignment, resource.MustParse("200m"), resource.MustParse("0"), false, freeCPU, freeMemory)
+		assert.Equal(t, topologyFitOK, fit)
+	})
+
+	t.Run("exhausted but satisfiable in principle", func(t *testing.T) {
+		fit := checkTopologyFit(assignment, resource.MustParse("1"), resource.MustParse("0"), false, freeCPU, freeMemory)
+		infeasible, reason := topologyFitReason(fit)
+		assert.False(t, infeasible)
+		assert.Equal(t, TopologyResourcesExhausted, reason)
+	})
+
+	t.Run("single NUMA node policy rejects growth once spread across nodes", func(t *testing.T) {
+		spread := []numaAssignment{{NUMANodeID: 0}, {NUMANodeID: 1}}
+		fit := checkTopologyFit(spread, resource.MustParse("100m"), resource.MustParse("0"), true, freeCPU, freeMemory)
+		infeasible, reason := topologyFitReason(fit)
+		assert.True(t, infeasible)
+		assert.Equal(t, TopologyConstraintsNotSatisfiable, reason)
+	})
+
+	t.Run("no pinned assignment is always OK", func(t *testing.T) {
+		fit := checkTopologyFit(nil, resource.MustParse("100"), resource.MustParse("0"), false, freeCPU, freeMemory)
+		assert.Equal(t, topologyFitOK, fit)
+	})
+}
+
+func TestCheckTopologyFitWholeNodeFailsWhenOneSocketIsSaturated(t *testing.T) {
+	// Two sockets: node 0 is saturated, node 1 has room to spare. Summed
+	// across the whole node there's plenty of free CPU for the delta, but
+	// the pod is pinned to node 0 alone, so the resize must still be
+	// treated as exhausted rather than OK.
+	assignment := []numaAssignment{{NUMANodeID: 0}}
+	freeCPU := func(node int) resource.Quantity {
+		if node == 0 {
+			return resource.MustParse("100m")
+		}
+		return resource.MustParse("4")
+	}
+	freeMemory := func(int) resource.Quantity { return resource.MustParse("1Gi") }
+
+	fit := checkTopologyFit(assignment, resource.MustParse("500m"), resource.MustParse("0"), false, freeCPU, freeMemory)
+
+	infeasible, reason := topologyFitReason(fit)
+	assert.False(t, infeasible, "exhausted on the pinned socket should be retried, not rejected outright")
+	assert.Equal(t, TopologyResourcesExhausted, reason)
+}
+
+func TestRecordTopologyFit(t *testing.T) {
+	tests := []struct {
+		name        string
+		fit         topologyFit
+		wantVerdict ResizeVerdict
+	}{
+		{name: "ok admits", fit: topologyFitOK, wantVerdict: ResizeVerdictAdmit},
+		{name: "exhausted defers", fit: topologyFitExhausted, wantVerdict: ResizeVerdictDeferred},
+		{name: "unsatisfiable is infeasible", fit: topologyFitUnsatisfiable, wantVerdict: ResizeVerdictInfeasible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := newResizeDecisionLog(1)
+			decision := recordTopologyFit(log, "pod-1", resource.MustParse("1"), tt.fit)
+			assert.Equal(t, tt.wantVerdict, decision.Verdict)
+			assert.Equal(t, []ResizeDecision{decision}, log.forPod("pod-1"))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
+)
+
+// watchConnectionState runs for the lifetime of conn, recording
+// connectivity transitions and, if the connection reaches
+// TransientFailure or Shutdown while the driver's socket is gone or
+// replaced (a driver restart typically recreates the socket file), evicts
+// the cached connection so the next call re-dials instead of continuing
+// to hand out a connection that will never recover on its own.
+//
+// It exits once p.backgroundCtx is cancelled (the plugin is being
+// unregistered) or once it has evicted the connection it was watching.
+func (p *DRAPlugin) watchConnectionState(conn *grpc.ClientConn) {
+	ctx := p.backgroundCtx
+	logger := klog.FromContext(ctx)
+	// The socket this connection was established against, so that a socket
+	// file recreated at the same path can be told apart from it.
+	initialSocket, _ := os.Stat(p.endpoint)
+
+	state := conn.GetState()
+	metrics.DRAPluginConnectionState.WithLabelValues(p.driverName, state.String()).Set(1)
+
+	for {
+		if !conn.WaitForStateChange(ctx, state) {
+			// backgroundCtx was cancelled: the plugin is shutting down.
+			return
+		}
+
+		metrics.DRAPluginConnectionState.WithLabelValues(p.driverName, state.String()).Set(0)
+		state = conn.GetState()
+		metrics.DRAPluginConnectionState.WithLabelValues(p.driverName, state.String()).Set(1)
+		logger.V(4).Info("DRA plugin gRPC connection state changed", "driverName", p.driverName, "state", state)
+
+		if state != connectivity.TransientFailure && state != connectivity.Shutdown {
+			p.setHealthy(true)
+			continue
+		}
+
+		p.setHealthy(false)
+		if socket, err := os.Stat(p.endpoint); err == nil && initialSocket != nil && os.SameFile(initialSocket, socket) {
+			// The same socket is still there; this may just be a brief
+			// blip, so give the connection a chance to recover on its own
+			// before forcing a redial.
+			continue
+		}
+
+		logger.V(2).Info("DRA driver socket is gone or was replaced, forcing reconnect", "driverName", p.driverName, "endpoint", p.endpoint)
+		p.mutex.Lock()
+		if p.conn == conn {
+			_ = conn.Close()
+			p.conn = nil
+		}
+		p.mutex.Unlock()
+		return
+	}
+}
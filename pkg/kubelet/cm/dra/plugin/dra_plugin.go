@@ -21,12 +21,15 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"k8s.io/klog/v2"
@@ -57,6 +60,10 @@ type DRAPlugin struct {
 	endpoint          string
 	chosenService     string // e.g. drapbv1beta1.DRAPluginService
 	clientCallTimeout time.Duration
+	connectTimeout    time.Duration
+	retryPolicy       *RetryPolicy
+	healthy           atomic.Bool
+	tlsConfig         *TLSConfig
 }
 
 func (p *DRAPlugin) getOrCreateGRPCConn() (*grpc.ClientConn, error) {
@@ -72,31 +79,48 @@ func (p *DRAPlugin) getOrCreateGRPCConn() (*grpc.ClientConn, error) {
 
 	network := "unix"
 	logger.V(4).Info("Creating new gRPC connection", "protocol", network, "endpoint", p.endpoint)
-	// grpc.Dial is deprecated. grpc.NewClient should be used instead.
-	// For now this gets ignored because this function is meant to establish
-	// the connection, with the one second timeout below. Perhaps that
-	// approach should be reconsidered?
-	//nolint:staticcheck
-	conn, err := grpc.Dial(
-		p.endpoint,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	creds, err := p.tlsConfig.transportCredentials(p.driverName)
+	if err != nil {
+		return nil, err
+	}
+
+	// grpc.NewClient does not block and does not attempt to connect
+	// immediately, unlike the deprecated grpc.Dial: the channel starts in
+	// Idle and stays there until an RPC is attempted or Connect is called
+	// explicitly. We call Connect below and wait for Ready ourselves so
+	// that a driver that's down is reported here, at call time, instead
+	// of surfacing as a mysterious timeout on the first real RPC; once
+	// established, ongoing readiness is the watchdog's job.
+	// NewClient resolves a target without a scheme through DNS; the
+	// passthrough scheme hands the socket path to the dialer unchanged.
+	conn, err := grpc.NewClient(
+		"passthrough:///"+p.endpoint,
+		grpc.WithTransportCredentials(creds),
 		grpc.WithContextDialer(func(ctx context.Context, target string) (net.Conn, error) {
 			return (&net.Dialer{}).DialContext(ctx, network, target)
 		}),
-		grpc.WithChainUnaryInterceptor(newMetricsInterceptor(p.driverName)),
+		grpc.WithChainUnaryInterceptor(newRetryInterceptor(p.retryPolicy), newMetricsInterceptor(p.driverName)),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	conn.Connect()
+	waitCtx, cancel := context.WithTimeout(context.Background(), p.connectTimeout)
 	defer cancel()
-
-	if ok := conn.WaitForStateChange(ctx, connectivity.Connecting); !ok {
-		return nil, errors.New("timed out waiting for gRPC connection to be ready")
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			break
+		}
+		if !conn.WaitForStateChange(waitCtx, state) {
+			return nil, errors.New("timed out waiting for gRPC connection to be ready")
+		}
 	}
 
 	p.conn = conn
+	p.setHealthy(true)
+	go p.watchConnectionState(conn)
 	return p.conn, nil
 }
 
@@ -104,6 +128,24 @@ func (p *DRAPlugin) DriverName() string {
 	return p.driverName
 }
 
+// Healthy reports whether the cached gRPC connection to the driver is
+// currently usable. It is updated by watchConnectionState as the
+// connection transitions between states; it does not itself probe the
+// driver.
+func (p *DRAPlugin) Healthy() bool {
+	return p.healthy.Load()
+}
+
+func (p *DRAPlugin) setHealthy(healthy bool) {
+	p.healthy.Store(healthy)
+}
+
+// NodePrepareResources calls the driver's NodePrepareResources rpc. If the
+// plugin was registered with a RetryPolicy, transient failures (as
+// determined by RetryPolicy.RetryableStatusCodes) are retried
+// automatically; the driver must therefore treat repeated calls for the
+// same claim as safe to repeat. A context cancellation or deadline from
+// the caller short-circuits any further retries.
 func (p *DRAPlugin) NodePrepareResources(
 	ctx context.Context,
 	req *drapbv1beta1.NodePrepareResourcesRequest,
@@ -117,6 +159,7 @@ func (p *DRAPlugin) NodePrepareResources(
 		return nil, err
 	}
 
+	ctx = stampRequestID(ctx)
 	ctx, cancel := context.WithTimeout(ctx, p.clientCallTimeout)
 	defer cancel()
 
@@ -137,6 +180,9 @@ func (p *DRAPlugin) NodePrepareResources(
 	return response, err
 }
 
+// NodeUnprepareResources calls the driver's NodeUnprepareResources rpc,
+// subject to the same retry and idempotency requirements documented on
+// NodePrepareResources.
 func (p *DRAPlugin) NodeUnprepareResources(
 	ctx context.Context,
 	req *drapbv1beta1.NodeUnprepareResourcesRequest,
@@ -150,6 +196,7 @@ func (p *DRAPlugin) NodeUnprepareResources(
 		return nil, err
 	}
 
+	ctx = stampRequestID(ctx)
 	ctx, cancel := context.WithTimeout(ctx, p.clientCallTimeout)
 	defer cancel()
 
@@ -172,9 +219,48 @@ func (p *DRAPlugin) NodeUnprepareResources(
 
 func newMetricsInterceptor(driverName string) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply any, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if deadline, ok := ctx.Deadline(); ok {
+			metrics.DRAGRPCDeadlineRemaining.WithLabelValues(driverName, method).Observe(time.Until(deadline).Seconds())
+		}
+
+		var peerInfo peer.Peer
+		opts = append(opts, grpc.Peer(&peerInfo))
+
 		start := time.Now()
 		err := invoker(ctx, method, req, reply, conn, opts...)
-		metrics.DRAGRPCOperationsDuration.WithLabelValues(driverName, method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		// attemptFromContext reflects the retry attempt stamped by
+		// newRetryInterceptor, which runs before this interceptor in the
+		// chain and calls us once per attempt, so retries are observable
+		// as separate data points instead of being folded into one
+		// aggregate observation for the whole retry loop.
+		attempt := attemptFromContext(ctx)
+		observation := metrics.DRAGRPCOperationsDuration.WithLabelValues(driverName, method, status.Code(err).String(), strconv.Itoa(attempt), cancelSource(ctx, err))
+		if exemplarObserver, ok := observation.(prometheus.ExemplarObserver); ok && peerInfo.AuthInfo != nil {
+			if spiffeID := peerSPIFFEID(peerInfo.AuthInfo); spiffeID != "" {
+				exemplarObserver.ObserveWithExemplar(time.Since(start).Seconds(), prometheus.Labels{"peer_identity": spiffeID})
+				return err
+			}
+		}
+		observation.Observe(time.Since(start).Seconds())
 		return err
 	}
 }
+
+// cancelSource distinguishes why a call ended in a non-nil error: the
+// caller cancelling the context explicitly, the caller's deadline
+// expiring, the server returning an error of its own, or (when err is nil)
+// no cancellation at all. Operators use this to tell "the driver was slow"
+// apart from "the caller gave up" apart from "the driver rejected the
+// request".
+func cancelSource(ctx context.Context, err error) string {
+	switch {
+	case err == nil:
+		return "none"
+	case errors.Is(ctx.Err(), context.Canceled):
+		return "client"
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return "deadline"
+	default:
+		return "server"
+	}
+}
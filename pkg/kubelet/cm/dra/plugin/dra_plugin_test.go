@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// newTestDRAPlugin starts a bare gRPC server listening on a unix socket and
+// returns a DRAPlugin pointed at it, along with the *grpc.Server so the test
+// can register services or shut it down.
+func newTestDRAPlugin(t *testing.T) (*DRAPlugin, *grpc.Server) {
+	t.Helper()
+	sockPath := filepath.Join(t.TempDir(), "dra.sock")
+	lis, err := net.Listen("unix", sockPath)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	p := &DRAPlugin{
+		driverName:        "test-driver",
+		backgroundCtx:     ctx,
+		cancel:            func(error) {},
+		endpoint:          sockPath,
+		connectTimeout:    5 * time.Second,
+		clientCallTimeout: 5 * time.Second,
+	}
+	return p, server
+}
+
+// TestGetOrCreateGRPCConnReachesReady guards against the connection getting
+// stuck in connectivity.Idle forever: getOrCreateGRPCConn must kick the
+// channel with Connect and wait for Ready, not for a state the channel
+// never leaves on its own.
+func TestGetOrCreateGRPCConnReachesReady(t *testing.T) {
+	p, _ := newTestDRAPlugin(t)
+
+	conn, err := p.getOrCreateGRPCConn()
+	require.NoError(t, err)
+	require.Equal(t, connectivity.Ready, conn.GetState())
+	require.True(t, p.Healthy())
+}
+
+func TestGetOrCreateGRPCConnTimesOutAgainstDeadSocket(t *testing.T) {
+	p := &DRAPlugin{
+		driverName:        "test-driver",
+		backgroundCtx:     context.Background(),
+		endpoint:          filepath.Join(t.TempDir(), "does-not-exist.sock"),
+		connectTimeout:    200 * time.Millisecond,
+		clientCallTimeout: time.Second,
+	}
+
+	_, err := p.getOrCreateGRPCConn()
+	require.Error(t, err)
+}
+
+// countingUnaryInterceptor counts how many times it is invoked, standing in
+// for newMetricsInterceptor to verify the interceptor chain order: it must
+// be invoked once per retry attempt, not once for the whole retry loop.
+func countingUnaryInterceptor(count *atomic.Int32) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		count.Add(1)
+		return invoker(ctx, method, req, reply, conn, opts...)
+	}
+}
+
+// TestRetryInterceptorWrapsPerAttemptInterceptor verifies the chain order
+// getOrCreateGRPCConn builds: newRetryInterceptor must be the outer
+// interceptor so that an inner per-attempt interceptor (standing in for
+// newMetricsInterceptor) is invoked once per retry, not once for the whole
+// retry loop.
+func TestRetryInterceptorWrapsPerAttemptInterceptor(t *testing.T) {
+	var calls atomic.Int32
+	alwaysUnavailable := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+	perAttemptInterceptor := countingUnaryInterceptor(&calls)
+	retryInterceptor := newRetryInterceptor(&RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []codes.Code{codes.Unavailable}})
+
+	err := retryInterceptor(context.Background(), "/Test/Method", nil, nil, nil, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return perAttemptInterceptor(ctx, method, req, reply, cc, alwaysUnavailable, opts...)
+	})
+	require.Error(t, err)
+	require.Equal(t, int32(3), calls.Load(), "the per-attempt interceptor must run once per retry attempt when it is chained inside the retry interceptor")
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"k8s.io/klog/v2"
+	drapbv1beta1 "k8s.io/kubelet/pkg/apis/dra/v1beta1"
+	drapbv1beta2 "k8s.io/kubelet/pkg/apis/dra/v1beta2"
+)
+
+// ClaimResult is the per-claim outcome delivered by the NodePrepareResources
+// response.
+type ClaimResult = drapbv1beta1.NodePrepareResourceResponse
+
+// NodePrepareResourcesStreaming drives the driver's NodePrepareResources rpc
+// and invokes onClaimResult once per claim as results become available.
+//
+// When the driver advertised drapbv1beta2.DRAPluginService, this uses that
+// service's server-streaming NodePrepareResourcesStream rpc: each message the
+// driver sends carries whichever claims it has finished since the last one,
+// so onClaimResult fires (and callers can let a pod whose claims are already
+// ready start) well before slower sibling claims in the same request finish.
+// For any other chosenService (the drapbv1beta1 and v1alpha4 services are
+// both unary-only), this falls back to the unary NodePrepareResources call
+// and delivers all results through onClaimResult once it returns.
+func (p *DRAPlugin) NodePrepareResourcesStreaming(
+	ctx context.Context,
+	req *drapbv1beta1.NodePrepareResourcesRequest,
+	onClaimResult func(claimUID string, result *ClaimResult) error,
+) error {
+	logger := klog.FromContext(ctx)
+
+	if p.chosenService != drapbv1beta2.DRAPluginService {
+		logger.V(4).Info("Driver does not advertise the streaming service, falling back to unary rpc", "driverName", p.driverName, "chosenService", p.chosenService)
+		response, err := p.NodePrepareResources(ctx, req)
+		if err != nil {
+			return err
+		}
+		for claimUID, result := range response.Claims {
+			if err := onClaimResult(claimUID, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	conn, err := p.getOrCreateGRPCConn()
+	if err != nil {
+		return err
+	}
+
+	ctx = stampRequestID(ctx)
+	// v1beta2 only adds the streaming rpc; it reuses v1beta1's request and
+	// per-claim result messages rather than redefining them, the same way
+	// NodePrepareResources above reuses them for the older v1alpha4 service
+	// via V1Alpha4ClientWrapper.
+	stream, err := drapbv1beta2.NewDRAPluginClient(conn).NodePrepareResourcesStream(ctx, req)
+	if err != nil {
+		return fmt.Errorf("opening NodePrepareResourcesStream: %w", err)
+	}
+
+	delivered := make(map[string]bool, len(req.Claims))
+	for {
+		partial, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("receiving from NodePrepareResourcesStream: %w", err)
+		}
+		logger.V(4).Info("Received partial NodePrepareResourcesStream response", "claims", len(partial.Claims))
+		for claimUID, result := range partial.Claims {
+			if delivered[claimUID] {
+				// The driver is allowed to repeat a claim it already
+				// reported, e.g. to correct itself before the stream
+				// ends; only the first delivery unblocks the caller.
+				continue
+			}
+			delivered[claimUID] = true
+			if err := onClaimResult(claimUID, result); err != nil {
+				return err
+			}
+		}
+	}
+
+	for claimUID := range req.Claims {
+		if !delivered[claimUID] {
+			return fmt.Errorf("driver closed NodePrepareResourcesStream without a result for claim %q", claimUID)
+		}
+	}
+	return nil
+}
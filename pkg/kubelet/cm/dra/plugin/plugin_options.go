@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// defaultConnectTimeout is how long getOrCreateGRPCConn waits for the initial
+// connection to the driver's socket to become ready. Slow
+// driver sockets on heavily loaded nodes can trip the previous hard-coded
+// one second deadline, so this is now part of PluginOptions.
+const defaultConnectTimeout = time.Second
+
+// defaultMaxRetryAttempts is used when a driver registers without an
+// explicit RetryPolicy. A value of 1 means "call once, do not retry",
+// which preserves the behavior of plugins registered before this option
+// existed.
+const defaultMaxRetryAttempts = 1
+
+// PluginOptions holds the per-driver gRPC client configuration that is set
+// at registration time via RegisterPlugin. All fields are optional; the
+// zero value of PluginOptions results in the historical behavior (a single
+// 45s call, no retries, one second connect timeout).
+type PluginOptions struct {
+	// ClientCallTimeout bounds each individual NodePrepareResources or
+	// NodeUnprepareResources call, including retries. If zero,
+	// defaultClientCallTimeout is used.
+	ClientCallTimeout time.Duration
+
+	// ConnectTimeout bounds how long the plugin waits for a freshly
+	// dialed gRPC connection to reach connectivity.Ready. If zero,
+	// defaultConnectTimeout is used.
+	ConnectTimeout time.Duration
+
+	// RetryPolicy controls retries of transient gRPC failures. A nil
+	// RetryPolicy disables retries (the call is attempted once).
+	RetryPolicy *RetryPolicy
+
+	// TLSConfig opts the driver's socket into mTLS. A nil TLSConfig keeps
+	// the historical behavior of dialing the unix socket with insecure
+	// credentials.
+	TLSConfig *TLSConfig
+}
+
+// TLSConfig points at the material a driver advertised at registration
+// time for authenticating its end of the gRPC connection. It is meant for
+// multi-tenant nodes, or nodes where the driver runs with less trust than
+// the kubelet, where a bare unix socket isn't a strong enough boundary.
+type TLSConfig struct {
+	// Dir is a directory containing ca.crt, tls.crt and tls.key. All
+	// three files must be present; registration fails otherwise.
+	Dir string
+
+	// SPIFFETrustDomain, if set, is matched against the trust domain of
+	// the SPIFFE ID presented in the driver's leaf certificate.
+	SPIFFETrustDomain string
+
+	// AllowedSPIFFEIDs is the allow-list of SPIFFE IDs (typically
+	// derived from the driver name by the caller) that the driver's
+	// certificate is permitted to present. The connection is refused if
+	// the peer's SPIFFE ID isn't in this list; an empty list accepts any
+	// SPIFFE ID that passes the SPIFFETrustDomain check.
+	AllowedSPIFFEIDs []string
+}
+
+// RetryPolicy describes how a DRAPlugin retries a gRPC call that failed
+// with a retryable status code. NodePrepareResources and
+// NodeUnprepareResources must be idempotent: the driver is expected to
+// tolerate repeated invocations for the same claim, since a retried call
+// may reach the driver after a previous attempt actually succeeded but the
+// response was lost.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	// one. A value <= 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+
+	// Multiplier scales the backoff after each attempt. A value <= 1 is
+	// treated as 2 (the gRPC default).
+	Multiplier float64
+
+	// Jitter is the fraction (0.0-1.0) of random jitter applied to each
+	// computed backoff, to avoid synchronized retries across many pods
+	// hitting the same driver at once.
+	Jitter float64
+
+	// RetryableStatusCodes lists the gRPC status codes that are
+	// considered transient and therefore worth retrying. Typical values
+	// are Unavailable, DeadlineExceeded and ResourceExhausted.
+	RetryableStatusCodes []codes.Code
+}
+
+func (o *PluginOptions) clientCallTimeout() time.Duration {
+	if o == nil || o.ClientCallTimeout == 0 {
+		return defaultClientCallTimeout
+	}
+	return o.ClientCallTimeout
+}
+
+func (o *PluginOptions) connectTimeout() time.Duration {
+	if o == nil || o.ConnectTimeout == 0 {
+		return defaultConnectTimeout
+	}
+	return o.ConnectTimeout
+}
+
+func (o *PluginOptions) retryPolicy() *RetryPolicy {
+	if o == nil {
+		return nil
+	}
+	return o.RetryPolicy
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts <= 0 {
+		return defaultMaxRetryAttempts
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) isRetryable(code codes.Code) bool {
+	if p == nil {
+		return false
+	}
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
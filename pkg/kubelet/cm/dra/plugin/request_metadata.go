@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/klog/v2"
+)
+
+// kubeletRequestIDHeader is the gRPC metadata key under which kubelet
+// stamps a correlation ID for each NodePrepareResources/
+// NodeUnprepareResources call, so that driver logs can be cross-referenced
+// with kubelet logs for the same call.
+const kubeletRequestIDHeader = "kubelet-request-id"
+
+// stampRequestID attaches a freshly generated kubelet-request-id to the
+// outgoing gRPC metadata and adds the same ID to the context's logger as
+// "kubeletRequestID", so that anything logged through the returned context
+// (such as the retry interceptor's messages) can be correlated with the
+// driver's logs for the same call.
+func stampRequestID(ctx context.Context) context.Context {
+	id := string(uuid.NewUUID())
+	ctx = klog.NewContext(ctx, klog.FromContext(ctx).WithValues("kubeletRequestID", id))
+	return metadata.AppendToOutgoingContext(ctx, kubeletRequestIDHeader, id)
+}
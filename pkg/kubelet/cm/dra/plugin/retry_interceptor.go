@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"k8s.io/klog/v2"
+)
+
+// newRetryInterceptor returns a gRPC client interceptor that retries a call
+// as long as it keeps failing with a status code that retryPolicy
+// considers retryable, up to retryPolicy.maxAttempts() attempts. It is
+// chained before newMetricsInterceptor (i.e. it wraps it) so that every
+// attempt, including retries, invokes newMetricsInterceptor separately and
+// shows up in DRAGRPCOperationsDuration with its own "attempt" label,
+// instead of the whole retry loop being folded into one observation.
+//
+// Callers of NodePrepareResources/NodeUnprepareResources must tolerate
+// repeated invocations for the same claim: a retry can legitimately reach
+// the driver after an earlier attempt already succeeded but its response
+// was lost to a transient network failure.
+func newRetryInterceptor(retryPolicy *RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		logger := klog.FromContext(ctx)
+		maxAttempts := retryPolicy.maxAttempts()
+
+		var err error
+		backoff := retryPolicy.initialBackoff()
+		for attempt := 1; attempt <= maxAttempts; attempt++ {
+			err = invoker(withAttempt(ctx, attempt), method, req, reply, conn, opts...)
+			if err == nil {
+				return nil
+			}
+
+			if ctx.Err() != nil {
+				// The caller cancelled or its deadline expired; retrying
+				// would just fail again for the same reason.
+				return err
+			}
+
+			code := status.Code(err)
+			if attempt == maxAttempts || !retryPolicy.isRetryable(code) {
+				return err
+			}
+
+			logger.V(4).Info("Retrying DRA gRPC call after transient failure", "method", method, "attempt", attempt, "code", code, "backoff", backoff)
+			timer := time.NewTimer(jitter(backoff, retryPolicy.Jitter))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			backoff = retryPolicy.nextBackoff(backoff)
+		}
+		return err
+	}
+}
+
+func (p *RetryPolicy) initialBackoff() time.Duration {
+	if p == nil || p.InitialBackoff <= 0 {
+		return 100 * time.Millisecond
+	}
+	return p.InitialBackoff
+}
+
+func (p *RetryPolicy) nextBackoff(current time.Duration) time.Duration {
+	multiplier := 2.0
+	if p != nil && p.Multiplier > 1 {
+		multiplier = p.Multiplier
+	}
+	next := time.Duration(float64(current) * multiplier)
+	maxBackoff := p.maxBackoff()
+	if next > maxBackoff {
+		next = maxBackoff
+	}
+	return next
+}
+
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p == nil || p.MaxBackoff <= 0 {
+		return 5 * time.Second
+	}
+	return p.MaxBackoff
+}
+
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	delta := float64(d) * fraction
+	return d - time.Duration(delta) + time.Duration(rand.Float64()*2*delta) //nolint:gosec // jitter does not need to be cryptographically secure
+}
+
+type attemptKey struct{}
+
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// attemptFromContext returns the 1-based attempt number stamped by
+// newRetryInterceptor, or 1 if the call was never wrapped by it (e.g. in
+// tests that invoke a gRPC client directly).
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
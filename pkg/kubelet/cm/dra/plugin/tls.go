@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// transportCredentials returns the credentials getOrCreateGRPCConn should
+// dial with: insecure unix-domain-socket credentials by default, or mTLS
+// layered on top of the same unix socket when the driver advertised a
+// TLSConfig at registration time. The unix-socket dialer itself is
+// unaffected either way; TLS only changes what runs over that connection.
+func (c *TLSConfig) transportCredentials(driverName string) (credentials.TransportCredentials, error) {
+	if c == nil {
+		return insecureCredentials(), nil
+	}
+
+	caFile := filepath.Join(c.Dir, "ca.crt")
+	certFile := filepath.Join(c.Dir, "tls.crt")
+	keyFile := filepath.Join(c.Dir, "tls.key")
+	for _, f := range []string{caFile, certFile, keyFile} {
+		if _, err := os.Stat(f); err != nil {
+			return nil, fmt.Errorf("DRA driver %q advertised a TLS config directory %q but %s is missing: %w", driverName, c.Dir, f, err)
+		}
+	}
+
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA certificate for DRA driver %q: %w", driverName, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no valid certificates found in %s for DRA driver %q", caFile, driverName)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS key pair for DRA driver %q: %w", driverName, err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return c.verifySPIFFEID(driverName, rawCerts)
+		},
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// verifySPIFFEID checks that the leaf certificate's SPIFFE URI SAN is both
+// in the configured trust domain and on the allow-list for this driver. An
+// unset trust domain or an empty allow-list skips that part of the check.
+func (c *TLSConfig) verifySPIFFEID(driverName string, rawCerts [][]byte) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("DRA driver %q presented no certificate", driverName)
+	}
+	leaf, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return fmt.Errorf("parsing certificate presented by DRA driver %q: %w", driverName, err)
+	}
+
+	for _, uri := range leaf.URIs {
+		if uri.Scheme != "spiffe" {
+			continue
+		}
+		if c.SPIFFETrustDomain != "" && uri.Host != c.SPIFFETrustDomain {
+			continue
+		}
+		if len(c.AllowedSPIFFEIDs) == 0 || slices.Contains(c.AllowedSPIFFEIDs, uri.String()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("DRA driver %q did not present a SPIFFE ID matching the configured trust domain/allow-list", driverName)
+}
+
+// peerSPIFFEID extracts the SPIFFE ID of the connection's peer, for use as
+// an exemplar label on the metrics interceptor. It returns "" when the
+// connection isn't using TLS or carries no SPIFFE URI SAN.
+func peerSPIFFEID(authInfo credentials.AuthInfo) string {
+	tlsInfo, ok := authInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return ""
+	}
+	for _, uri := range tlsInfo.State.PeerCertificates[0].URIs {
+		if uri.Scheme == "spiffe" {
+			return uri.String()
+		}
+	}
+	return ""
+}
+
+func insecureCredentials() credentials.TransportCredentials {
+	return insecure.NewCredentials()
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// KubeletSubsystem is the prometheus subsystem kubelet-owned metrics in
+// this package register under.
+const KubeletSubsystem = "kubelet"
+
+var (
+	// DRAGRPCDeadlineRemaining records, for every DRA gRPC call that has a
+	// deadline, how much time was left on it when the call started. A
+	// distribution skewed toward zero indicates callers are racing their
+	// own timeout rather than the driver being slow.
+	DRAGRPCDeadlineRemaining = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: KubeletSubsystem,
+			Name:      "dra_grpc_deadline_remaining_seconds",
+			Help:      "Time remaining on the caller's context deadline when a DRA plugin gRPC call started, by driver and method.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"driver_name", "method"},
+	)
+
+	// DRAGRPCOperationsDuration records the duration of every DRA gRPC
+	// call attempt, labeled by the outcome, retry attempt number, and
+	// what (if anything) triggered cancellation. attempt and
+	// cancel_source let operators distinguish "the driver itself is
+	// slow" from "a retry storm is amplifying load" from "callers are
+	// giving up before the driver responds".
+	DRAGRPCOperationsDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: KubeletSubsystem,
+			Name:      "dra_grpc_operations_duration_seconds",
+			Help:      "Duration of DRA plugin gRPC calls, by driver, method, gRPC status code, retry attempt, and cancellation source.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"driver_name", "method", "grpc_status_code", "attempt", "cancel_source"},
+	)
+
+	// DRAPluginConnectionState tracks which connectivity.State a DRA
+	// plugin's cached gRPC connection currently occupies: 1 for the
+	// current state, 0 for every state the connection most recently
+	// left, so a dashboard can plot occupancy over time per driver.
+	DRAPluginConnectionState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: KubeletSubsystem,
+			Name:      "dra_plugin_connection_state",
+			Help:      "Current gRPC connectivity state (1) or a state just left (0) for a DRA plugin's cached connection, by driver and state.",
+		},
+		[]string{"driver_name", "state"},
+	)
+
+	// KubeletPodResizeRetryAttemptsTotal counts every admission attempt of
+	// an in-place pod resize that came back Deferred, the first deferral
+	// included, labeled by the reason it was deferred, so operators can
+	// tell a resize that's retrying due
+	// to plain capacity shortfall apart from one stuck on foreign
+	// allocations, topology constraints, or a startup-boost revert.
+	KubeletPodResizeRetryAttemptsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: KubeletSubsystem,
+			Name:      "pod_resize_retry_attempts_total",
+			Help:      "Number of in-place pod resize admission attempts that came back Deferred, by the reason it was deferred.",
+		},
+		[]string{"reason"},
+	)
+
+	// KubeletPodResizeDeferredSeconds observes how long a resize spent in
+	// the Deferred state before its most recent retry attempt, from
+	// first observed shortfall to now.
+	KubeletPodResizeDeferredSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Subsystem: KubeletSubsystem,
+			Name:      "pod_resize_deferred_seconds",
+			Help:      "Time an in-place pod resize has spent in the Deferred state as of its most recent retry attempt.",
+			Buckets:   prometheus.DefBuckets,
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		DRAGRPCDeadlineRemaining,
+		DRAGRPCOperationsDuration,
+		DRAPluginConnectionState,
+		KubeletPodResizeRetryAttemptsTotal,
+		KubeletPodResizeDeferredSeconds,
+	)
+}
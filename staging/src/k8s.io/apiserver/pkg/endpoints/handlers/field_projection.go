@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+// This file implements the per-object re-encoding that applying
+// metav1.ListOptions.FieldProjection (the field itself lives in
+// apimachinery's types.go, not present in this checkout) to a List or
+// WatchList response requires: the object is re-encoded through an
+// unstructured round-trip keeping only the requested dotted paths, similar
+// in spirit to the PartialObjectMetadata projection but with a
+// caller-chosen field set instead of a fixed one. The REST storage/
+// negotiation code that would call ApplyFieldProjection while actually
+// serving a List/WatchList request is not part of this checkout (see
+// watch_table.go's serveWatch note for the same gap), so nothing here
+// calls it outside of tests; a real apiserver integration would invoke it
+// at the same point serveWatch/the list handler encode each object for the
+// response. client-go's
+// watchlist.ValidateFieldProjection rejects the same invariant violation
+// earlier, at request-construction time, but that is a client-side
+// convenience, not an enforcement point: a client that builds its
+// ListOptions by hand instead of going through that helper would never hit
+// it. ApplyFieldProjection re-checks the invariant itself so the apiserver
+// never honors a projection that drops the fields the watch/list machinery
+// requires, regardless of how the request was built.
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// requiredFieldProjectionPaths mirrors the invariant client-go's
+// watchlist.ValidateFieldProjection enforces client-side: every
+// FieldProjection must keep metadata.resourceVersion and metadata.uid,
+// since the reflector keys its store and tracks resourceVersion off these
+// two fields and the resulting objects would otherwise be unusable by the
+// very machinery requesting them.
+var requiredFieldProjectionPaths = []string{"metadata.resourceVersion", "metadata.uid"}
+
+// ApplyFieldProjection returns a copy of obj with only the fields named by
+// paths (dotted, e.g. "metadata.labels", "data.foo") populated. obj must
+// already be convertible to unstructured (true for anything coming out of
+// this server's own scheme).
+//
+// paths must include every path in requiredFieldProjectionPaths; this is
+// checked here, server-side, rather than trusted from the caller, since a
+// request that bypassed client-go's own validation could otherwise reach
+// this far with an invalid projection.
+func ApplyFieldProjection(obj runtime.Object, paths []string) (runtime.Object, error) {
+	if err := validateFieldProjectionPaths(paths); err != nil {
+		return nil, err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("converting to unstructured for field projection: %w", err)
+	}
+
+	projected := map[string]interface{}{}
+	for _, path := range paths {
+		segments := strings.Split(path, ".")
+		value, found, err := unstructured.NestedFieldNoCopy(content, segments...)
+		if err != nil {
+			return nil, fmt.Errorf("reading field %q: %w", path, err)
+		}
+		if !found {
+			continue
+		}
+		if err := unstructured.SetNestedField(projected, value, segments...); err != nil {
+			return nil, fmt.Errorf("setting field %q on projected object: %w", path, err)
+		}
+	}
+
+	// apiVersion/kind are always carried over so the result still
+	// round-trips through the scheme's decoder.
+	for _, typeField := range []string{"apiVersion", "kind"} {
+		if value, found := content[typeField]; found {
+			projected[typeField] = value
+		}
+	}
+
+	return &unstructured.Unstructured{Object: projected}, nil
+}
+
+// validateFieldProjectionPaths rejects a FieldProjection that would exclude
+// any path in requiredFieldProjectionPaths.
+func validateFieldProjectionPaths(paths []string) error {
+	seen := map[string]bool{}
+	for _, p := range paths {
+		seen[p] = true
+	}
+	for _, required := range requiredFieldProjectionPaths {
+		if !seen[required] {
+			return fmt.Errorf("fieldProjection must include %q", required)
+		}
+	}
+	return nil
+}
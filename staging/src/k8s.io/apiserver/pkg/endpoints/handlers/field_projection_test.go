@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestApplyFieldProjectionKeepsOnlyRequestedFields(t *testing.T) {
+	secret := &v1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "s1", Namespace: "ns1", Labels: map[string]string{"a": "b"}},
+		Data:       map[string][]byte{"foo": []byte("bar")},
+	}
+
+	projected, err := ApplyFieldProjection(secret, []string{"metadata.name", "metadata.labels", "metadata.resourceVersion", "metadata.uid"})
+	if err != nil {
+		t.Fatalf("ApplyFieldProjection: %v", err)
+	}
+
+	u, ok := projected.(*unstructured.Unstructured)
+	if !ok {
+		t.Fatalf("got %T, want *unstructured.Unstructured", projected)
+	}
+
+	name, _, _ := unstructured.NestedString(u.Object, "metadata", "name")
+	if name != "s1" {
+		t.Fatalf("got metadata.name %q, want %q", name, "s1")
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "metadata", "namespace"); found {
+		t.Fatalf("expected metadata.namespace to be excluded from the projection")
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "data"); found {
+		t.Fatalf("expected data to be excluded from the projection")
+	}
+}
+
+func TestApplyFieldProjectionSkipsMissingFields(t *testing.T) {
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s1"}}
+	projected, err := ApplyFieldProjection(secret, []string{"metadata.name", "metadata.annotations", "metadata.resourceVersion", "metadata.uid"})
+	if err != nil {
+		t.Fatalf("ApplyFieldProjection: %v", err)
+	}
+	u := projected.(*unstructured.Unstructured)
+	if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "metadata", "annotations"); found {
+		t.Fatalf("expected absent metadata.annotations to be skipped, not populated as empty")
+	}
+}
+
+// TestApplyFieldProjectionRejectsMissingRequiredPaths guards the server-side
+// enforcement of the FieldProjection invariant: a caller that reaches
+// ApplyFieldProjection without going through client-go's
+// watchlist.ValidateFieldProjection (or that bypasses it entirely) must
+// still be rejected here, not silently honored.
+func TestApplyFieldProjectionRejectsMissingRequiredPaths(t *testing.T) {
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s1"}}
+
+	for _, paths := range [][]string{
+		{"metadata.name"},
+		{"metadata.name", "metadata.resourceVersion"},
+		{"metadata.name", "metadata.uid"},
+	} {
+		if _, err := ApplyFieldProjection(secret, paths); err == nil {
+			t.Fatalf("ApplyFieldProjection(%v): expected error, got nil", paths)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+// This file provides the event-to-Table re-encoding a watch handler needs
+// to honor a negotiated "as=Table" response kind instead of returning 406:
+// wrap the watch.Interface a request would otherwise stream from in a
+// tableWatch before handing it to the streaming encoder. The request-level
+// wiring lives in serveWatch, which (along with the rest of this checkout's
+// REST storage/negotiation stack) is not part of this repository snapshot,
+// so that trigger point does not exist here for NewTableWatch to be called
+// from; this package only ships the re-encoding itself.
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// TableConvertor is the subset of rest.TableConvertor the watch table
+// encoder needs: turning a single object into the Table rows/columns a
+// client asked for.
+type TableConvertor interface {
+	ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error)
+}
+
+// tableWatch wraps a watch.Interface so every event's Object is replaced
+// with a *metav1.Table holding that object's single row, reusing the
+// resource's existing TableConvertor rather than requiring a parallel Table
+// encoding path per type.
+type tableWatch struct {
+	ctx       context.Context
+	delegate  watch.Interface
+	convertor TableConvertor
+	resultCh  chan watch.Event
+	stopCh    chan struct{}
+}
+
+// NewTableWatch returns a watch.Interface that re-encodes every event
+// delivered by delegate as a Table row via convertor, preserving the
+// event's Type (Added/Modified/Deleted/Bookmark/Error) unchanged. An event
+// whose object fails to convert is replaced by an Error event carrying a
+// metav1.Status.
+func NewTableWatch(ctx context.Context, delegate watch.Interface, convertor TableConvertor) watch.Interface {
+	w := &tableWatch{
+		ctx:       ctx,
+		delegate:  delegate,
+		convertor: convertor,
+		resultCh:  make(chan watch.Event),
+		stopCh:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *tableWatch) run() {
+	defer close(w.resultCh)
+	for {
+		select {
+		case evt, ok := <-w.delegate.ResultChan():
+			if !ok {
+				return
+			}
+			select {
+			case w.resultCh <- w.convert(evt):
+			case <-w.stopCh:
+				return
+			}
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+func (w *tableWatch) convert(evt watch.Event) watch.Event {
+	// Status objects (used for Error events and bookmarks in some
+	// watch implementations) are already in their final client-facing
+	// shape and pass through untouched.
+	if _, isStatus := evt.Object.(*metav1.Status); isStatus {
+		return evt
+	}
+
+	table, err := w.convertor.ConvertToTable(w.ctx, evt.Object, nil)
+	if err != nil {
+		return watch.Event{
+			Type: watch.Error,
+			Object: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: fmt.Sprintf("converting watch event to Table: %v", err),
+			},
+		}
+	}
+	// A Table encoded from a single object carries exactly one row;
+	// ListMeta/TypeMeta are left zero-valued the way per-event Tables
+	// from the non-streaming Table encoder already do.
+	return watch.Event{Type: evt.Type, Object: table}
+}
+
+func (w *tableWatch) Stop() {
+	close(w.stopCh)
+	w.delegate.Stop()
+}
+
+func (w *tableWatch) ResultChan() <-chan watch.Event {
+	return w.resultCh
+}
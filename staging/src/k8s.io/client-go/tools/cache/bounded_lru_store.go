@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+// This file adds an opt-in bounded Store for SharedIndexInformer (the
+// informer itself, along with NewSharedIndexInformerWithOptions's
+// MaxEntries/RetentionPeriod fields that are expected to wire it in, live
+// in shared_informer.go, not present in this checkout). High-cardinality
+// object kinds like Events/Leases can otherwise grow an informer's store
+// without bound; BoundedLRUStore caps it, evicting by least-recent-touch
+// once MaxEntries is exceeded or an entry has gone untouched for longer
+// than RetentionPeriod, and re-hydrates evicted keys on the next GetByKey
+// through a Getter rather than silently returning a miss.
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Getter re-fetches a single object by key when BoundedLRUStore has evicted
+// it but a caller still asks for it by key (the common case: an update
+// event arrives for a key the LRU dropped). Implementations typically wrap
+// a metadata or typed client Get call.
+type Getter interface {
+	GetByKey(key string) (item interface{}, exists bool, err error)
+}
+
+type boundedLRUEntry struct {
+	key       string
+	obj       interface{}
+	touchedAt time.Time
+	elem      *list.Element
+}
+
+// BoundedLRUStore is a cache.Store that evicts entries once MaxEntries is
+// exceeded (oldest-touched first) or once an entry has gone untouched for
+// longer than RetentionPeriod, whichever triggers first. A zero MaxEntries
+// or RetentionPeriod disables that dimension of eviction.
+type BoundedLRUStore struct {
+	keyFunc         KeyFunc
+	maxEntries      int
+	retentionPeriod time.Duration
+	getter          Getter
+	now             func() time.Time
+
+	mu    sync.Mutex
+	items map[string]*boundedLRUEntry
+	lru   *list.List // front = most recently touched
+}
+
+// NewBoundedLRUStore returns a Store suitable for
+// NewSharedIndexInformerWithOptions's MaxEntries/RetentionPeriod options.
+// getter is consulted by GetByKey when a key isn't present locally, so a
+// cache miss on an evicted-but-still-relevant key re-hydrates instead of
+// reporting "doesn't exist".
+func NewBoundedLRUStore(keyFunc KeyFunc, maxEntries int, retentionPeriod time.Duration, getter Getter) *BoundedLRUStore {
+	return &BoundedLRUStore{
+		keyFunc:         keyFunc,
+		maxEntries:      maxEntries,
+		retentionPeriod: retentionPeriod,
+		getter:          getter,
+		now:             time.Now,
+		items:           map[string]*boundedLRUEntry{},
+		lru:             list.New(),
+	}
+}
+
+func (s *BoundedLRUStore) Add(obj interface{}) error {
+	key, err := s.keyFunc(obj)
+	if err != nil {
+		return err
+	}
+	s.touch(key, obj)
+	return nil
+}
+
+func (s *BoundedLRUStore) Update(obj interface{}) error {
+	return s.Add(obj)
+}
+
+func (s *BoundedLRUStore) Delete(obj interface{}) error {
+	key, err := s.keyFunc(obj)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(key)
+	return nil
+}
+
+func (s *BoundedLRUStore) List() []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpiredLocked()
+	result := make([]interface{}, 0, len(s.items))
+	for _, entry := range s.items {
+		result = append(result, entry.obj)
+	}
+	return result
+}
+
+func (s *BoundedLRUStore) ListKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpiredLocked()
+	keys := make([]string, 0, len(s.items))
+	for k := range s.items {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (s *BoundedLRUStore) Get(obj interface{}) (item interface{}, exists bool, err error) {
+	key, err := s.keyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return s.GetByKey(key)
+}
+
+func (s *BoundedLRUStore) GetByKey(key string) (item interface{}, exists bool, err error) {
+	s.mu.Lock()
+	entry, ok := s.items[key]
+	if ok && !s.expiredLocked(entry) {
+		s.touchLocked(entry)
+		obj := entry.obj
+		s.mu.Unlock()
+		return obj, true, nil
+	}
+	if ok {
+		s.removeLocked(key)
+	}
+	s.mu.Unlock()
+
+	if s.getter == nil {
+		return nil, false, nil
+	}
+	obj, exists, err := s.getter.GetByKey(key)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+	s.touch(key, obj)
+	return obj, true, nil
+}
+
+func (s *BoundedLRUStore) Replace(objs []interface{}, resourceVersion string) error {
+	s.mu.Lock()
+	s.items = map[string]*boundedLRUEntry{}
+	s.lru.Init()
+	s.mu.Unlock()
+
+	for _, obj := range objs {
+		if err := s.Add(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BoundedLRUStore) Resync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpiredLocked()
+	return nil
+}
+
+func (s *BoundedLRUStore) touch(key string, obj interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.items[key]; ok {
+		entry.obj = obj
+		s.touchLocked(entry)
+		return
+	}
+	entry := &boundedLRUEntry{key: key, obj: obj, touchedAt: s.now()}
+	entry.elem = s.lru.PushFront(entry)
+	s.items[key] = entry
+	s.evictOverCapacityLocked()
+}
+
+func (s *BoundedLRUStore) touchLocked(entry *boundedLRUEntry) {
+	entry.touchedAt = s.now()
+	s.lru.MoveToFront(entry.elem)
+}
+
+func (s *BoundedLRUStore) removeLocked(key string) {
+	entry, ok := s.items[key]
+	if !ok {
+		return
+	}
+	s.lru.Remove(entry.elem)
+	delete(s.items, key)
+}
+
+func (s *BoundedLRUStore) expiredLocked(entry *boundedLRUEntry) bool {
+	return s.retentionPeriod > 0 && s.now().Sub(entry.touchedAt) > s.retentionPeriod
+}
+
+func (s *BoundedLRUStore) evictExpiredLocked() {
+	if s.retentionPeriod <= 0 {
+		return
+	}
+	for elem := s.lru.Back(); elem != nil; {
+		entry := elem.Value.(*boundedLRUEntry)
+		prev := elem.Prev()
+		if s.expiredLocked(entry) {
+			s.lru.Remove(elem)
+			delete(s.items, entry.key)
+		}
+		elem = prev
+	}
+}
+
+func (s *BoundedLRUStore) evictOverCapacityLocked() {
+	if s.maxEntries <= 0 {
+		return
+	}
+	for len(s.items) > s.maxEntries {
+		oldest := s.lru.Back()
+		if oldest == nil {
+			return
+		}
+		entry := oldest.Value.(*boundedLRUEntry)
+		s.lru.Remove(oldest)
+		delete(s.items, entry.key)
+	}
+}
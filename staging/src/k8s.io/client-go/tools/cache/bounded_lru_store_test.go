@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type boundedStoreTestObj struct {
+	name string
+}
+
+func boundedStoreTestKeyFunc(obj interface{}) (string, error) {
+	return obj.(*boundedStoreTestObj).name, nil
+}
+
+func TestBoundedLRUStoreEvictsOverMaxEntries(t *testing.T) {
+	store := NewBoundedLRUStore(boundedStoreTestKeyFunc, 3, 0, nil)
+	for i := 0; i < 5; i++ {
+		if err := store.Add(&boundedStoreTestObj{name: fmt.Sprintf("obj-%d", i)}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if len(store.List()) != 3 {
+		t.Fatalf("got %d entries, want 3 after exceeding MaxEntries", len(store.List()))
+	}
+	// The 3 most recently added should have survived; the oldest two
+	// should have been evicted.
+	if _, exists, _ := store.GetByKey("obj-0"); exists {
+		t.Fatalf("expected obj-0 to have been evicted")
+	}
+	if _, exists, _ := store.GetByKey("obj-4"); !exists {
+		t.Fatalf("expected obj-4 to still be present")
+	}
+}
+
+func TestBoundedLRUStoreTouchOnGetPreventsEviction(t *testing.T) {
+	store := NewBoundedLRUStore(boundedStoreTestKeyFunc, 2, 0, nil)
+	store.Add(&boundedStoreTestObj{name: "a"})
+	store.Add(&boundedStoreTestObj{name: "b"})
+
+	// Touch "a" so "b" becomes the least-recently-touched entry.
+	store.GetByKey("a")
+	store.Add(&boundedStoreTestObj{name: "c"})
+
+	if _, exists, _ := store.GetByKey("a"); !exists {
+		t.Fatalf("expected recently touched obj a to survive eviction")
+	}
+	if _, exists, _ := store.GetByKey("b"); exists {
+		t.Fatalf("expected least-recently-touched obj b to be evicted")
+	}
+}
+
+func TestBoundedLRUStoreEvictsExpiredByRetentionPeriod(t *testing.T) {
+	store := NewBoundedLRUStore(boundedStoreTestKeyFunc, 0, time.Minute, nil)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	store.Add(&boundedStoreTestObj{name: "a"})
+
+	store.now = func() time.Time { return now.Add(2 * time.Minute) }
+	if _, exists, _ := store.GetByKey("a"); exists {
+		t.Fatalf("expected obj a to be expired after RetentionPeriod elapsed")
+	}
+}
+
+type fakeGetter struct {
+	objs map[string]*boundedStoreTestObj
+}
+
+func (f fakeGetter) GetByKey(key string) (interface{}, bool, error) {
+	obj, ok := f.objs[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return obj, true, nil
+}
+
+func TestBoundedLRUStoreRehydratesEvictedKeyFromGetter(t *testing.T) {
+	getter := fakeGetter{objs: map[string]*boundedStoreTestObj{"a": {name: "a"}}}
+	store := NewBoundedLRUStore(boundedStoreTestKeyFunc, 1, 0, getter)
+	store.Add(&boundedStoreTestObj{name: "a"})
+	store.Add(&boundedStoreTestObj{name: "b"})
+	// Adding "b" with MaxEntries 1 evicted "a" from the LRU, but the getter
+	// knows about it, so GetByKey should re-hydrate rather than miss.
+	obj, exists, err := store.GetByKey("a")
+	if err != nil {
+		t.Fatalf("GetByKey: %v", err)
+	}
+	if !exists {
+		t.Fatalf("expected GetByKey to re-hydrate obj a via the getter")
+	}
+	if obj.(*boundedStoreTestObj).name != "a" {
+		t.Fatalf("got obj %+v, want name \"a\"", obj)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// CloudEventsBrokerTransport names the kind of broker connection a
+// CloudEventsSubscriber uses. NewCloudEventsListWatch does not read it and
+// contains no MQTT or webhook client of its own: the Subscriber alone
+// decides how the subscription is established.
+type CloudEventsBrokerTransport string
+
+const (
+	// CloudEventsBrokerMQTT names a Subscriber that subscribes to a topic on
+	// an MQTT broker.
+	CloudEventsBrokerMQTT CloudEventsBrokerTransport = "mqtt"
+	// CloudEventsBrokerHTTPWebhook names a Subscriber that registers an HTTP
+	// webhook the broker POSTs CloudEvents to.
+	CloudEventsBrokerHTTPWebhook CloudEventsBrokerTransport = "http-webhook"
+)
+
+// CloudEventsSubscriber is the minimal broker client NewCloudEventsListWatch
+// needs. A real deployment plugs in an MQTT client or a webhook HTTP server
+// behind this; tests can substitute a fake that feeds events synchronously.
+type CloudEventsSubscriber interface {
+	// Subscribe starts delivering CloudEvent payloads matching ceType to
+	// onEvent until the returned unsubscribe func is called. onEvent is
+	// called with the raw CloudEvent JSON payload.
+	Subscribe(ceType string, onEvent func(payload []byte)) (unsubscribe func(), err error)
+}
+
+// CloudEventsListWatchOptions configures NewCloudEventsListWatch.
+type CloudEventsListWatchOptions struct {
+	Transport  CloudEventsBrokerTransport
+	Subscriber CloudEventsSubscriber
+	// CEType is the base CloudEvent type this watch subscribes to, e.g.
+	// "io.k8s.core.v1.secret". The added/modified/deleted suffix is
+	// appended per event kind, matching the scheme documented for
+	// NewCloudEventsListWatch.
+	CEType string
+	// List is used to serve the initial List call an informer's reflector
+	// issues before falling back to Watch; CloudEvents are watch-only, so
+	// there's nothing in this package to synthesize a list from.
+	List func(options metav1.ListOptions) (runtime.Object, error)
+}
+
+// cloudEvent is the subset of the CloudEvents envelope this package needs to
+// translate a payload into a watch.Event. Brokers are expected to carry the
+// Kubernetes object as the CloudEvent data, JSON-encoded the same way the
+// apiserver would serialize it to the client's negotiated content type.
+type cloudEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// NewCloudEventsListWatch returns a cache.ListerWatcher that streams object
+// change notifications from a CloudEvents broker instead of polling the
+// apiserver's watch endpoint directly. CloudEvent payloads are expected to
+// use a ce-type suffix scheme of "<CEType>.added", "<CEType>.modified" and
+// "<CEType>.deleted" (e.g. "io.k8s.core.v1.secret.added"), which this
+// function maps onto watch.Added/watch.Modified/watch.Deleted.
+//
+// This is meant for edge/multi-cluster fan-out, where thousands of agents
+// would otherwise each need a long-lived HTTP/2 stream to kube-apiserver;
+// the broker absorbs that fan-out instead.
+func NewCloudEventsListWatch(opts CloudEventsListWatchOptions, newObject func() runtime.Object) *ListWatch {
+	return &ListWatch{
+		ListFunc: opts.List,
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return newCloudEventsWatch(opts, options, newObject)
+		},
+	}
+}
+
+type cloudEventsWatch struct {
+	resultCh    chan watch.Event
+	stopCh      chan struct{}
+	sendWG      sync.WaitGroup
+	unsubscribe func()
+	stopOnce    sync.Once
+}
+
+// send delivers evt to resultCh. Unlike tableWatch, cloudEventsWatch has no
+// single goroutine serializing delivery: the broker invokes Subscribe's
+// callback directly, possibly from several goroutines at once, so Stop
+// can't just defer a close the way a lone run() loop would. send is guarded
+// two ways instead: racing the resultCh send against stopCh gives a send
+// that's blocked (no reader left) a second, always-ready case to return
+// through once Stop closes stopCh, and sendWG lets Stop wait for every
+// in-flight send to have returned before it closes resultCh, so a send can
+// never still be inside its select when resultCh closes.
+func (w *cloudEventsWatch) send(evt watch.Event) {
+	w.sendWG.Add(1)
+	defer w.sendWG.Done()
+	select {
+	case w.resultCh <- evt:
+	case <-w.stopCh:
+	}
+}
+
+func newCloudEventsWatch(opts CloudEventsListWatchOptions, options metav1.ListOptions, newObject func() runtime.Object) (watch.Interface, error) {
+	w := &cloudEventsWatch{
+		resultCh: make(chan watch.Event),
+		stopCh:   make(chan struct{}),
+	}
+
+	for _, suffix := range []struct {
+		ceType  string
+		evtType watch.EventType
+	}{
+		{opts.CEType + ".added", watch.Added},
+		{opts.CEType + ".modified", watch.Modified},
+		{opts.CEType + ".deleted", watch.Deleted},
+	} {
+		evtType := suffix.evtType
+		unsubscribe, err := opts.Subscriber.Subscribe(suffix.ceType, func(payload []byte) {
+			obj, err := decodeCloudEvent(payload, newObject)
+			if err != nil {
+				w.send(watch.Event{Type: watch.Error, Object: &metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: fmt.Sprintf("decoding cloudevent: %v", err),
+				}})
+				return
+			}
+			w.send(watch.Event{Type: evtType, Object: obj})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("subscribing to %s: %w", suffix.ceType, err)
+		}
+		previous := w.unsubscribe
+		w.unsubscribe = func() {
+			if previous != nil {
+				previous()
+			}
+			unsubscribe()
+		}
+	}
+
+	_ = options.AllowWatchBookmarks // bookmarks have no meaning over a broker fan-out; resourceVersion ordering is left to the broker's own delivery guarantees.
+	return w, nil
+}
+
+func decodeCloudEvent(payload []byte, newObject func() runtime.Object) (runtime.Object, error) {
+	var evt cloudEvent
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(evt.Type, ".") {
+		return nil, fmt.Errorf("unrecognized ce-type %q", evt.Type)
+	}
+	obj := newObject()
+	if err := json.Unmarshal(evt.Data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (w *cloudEventsWatch) Stop() {
+	w.stopOnce.Do(func() {
+		if w.unsubscribe != nil {
+			// unsubscribe is expected to block until the broker client
+			// guarantees no further Subscribe callback invocations, so
+			// no new send can start once this returns.
+			w.unsubscribe()
+		}
+		close(w.stopCh)
+		w.sendWG.Wait()
+		close(w.resultCh)
+	})
+}
+
+func (w *cloudEventsWatch) ResultChan() <-chan watch.Event {
+	return w.resultCh
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type fakeSubscriber struct {
+	handlers map[string]func(payload []byte)
+}
+
+func (f *fakeSubscriber) Subscribe(ceType string, onEvent func(payload []byte)) (func(), error) {
+	if f.handlers == nil {
+		f.handlers = map[string]func(payload []byte){}
+	}
+	f.handlers[ceType] = onEvent
+	return func() { delete(f.handlers, ceType) }, nil
+}
+
+func (f *fakeSubscriber) deliver(ceType string, payload []byte) {
+	f.handlers[ceType](payload)
+}
+
+func TestCloudEventsListWatchTranslatesEventTypes(t *testing.T) {
+	subscriber := &fakeSubscriber{}
+	lw := NewCloudEventsListWatch(CloudEventsListWatchOptions{
+		Subscriber: subscriber,
+		CEType:     "io.k8s.core.v1.secret",
+	}, func() runtime.Object { return &v1.Secret{} })
+
+	w, err := lw.WatchFunc(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("WatchFunc: %v", err)
+	}
+	defer w.Stop()
+
+	payload := []byte(`{"type":"io.k8s.core.v1.secret.added","data":{"metadata":{"name":"s1"}}}`)
+	go subscriber.deliver("io.k8s.core.v1.secret.added", payload)
+
+	evt := <-w.ResultChan()
+	if evt.Type != watch.Added {
+		t.Fatalf("got event type %v, want %v", evt.Type, watch.Added)
+	}
+	secret, ok := evt.Object.(*v1.Secret)
+	if !ok {
+		t.Fatalf("got object of type %T, want *v1.Secret", evt.Object)
+	}
+	if secret.Name != "s1" {
+		t.Fatalf("got secret name %q, want %q", secret.Name, "s1")
+	}
+}
+
+func TestCloudEventsListWatchSurfacesDecodeErrorsAsWatchError(t *testing.T) {
+	subscriber := &fakeSubscriber{}
+	lw := NewCloudEventsListWatch(CloudEventsListWatchOptions{
+		Subscriber: subscriber,
+		CEType:     "io.k8s.core.v1.secret",
+	}, func() runtime.Object { return &v1.Secret{} })
+
+	w, err := lw.WatchFunc(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("WatchFunc: %v", err)
+	}
+	defer w.Stop()
+
+	go subscriber.deliver("io.k8s.core.v1.secret.added", []byte("not json"))
+
+	evt := <-w.ResultChan()
+	if evt.Type != watch.Error {
+		t.Fatalf("got event type %v, want %v", evt.Type, watch.Error)
+	}
+	if _, ok := evt.Object.(*metav1.Status); !ok {
+		t.Fatalf("got object of type %T, want *metav1.Status", evt.Object)
+	}
+}
+
+func TestCloudEventsListWatchStopUnsubscribes(t *testing.T) {
+	subscriber := &fakeSubscriber{}
+	lw := NewCloudEventsListWatch(CloudEventsListWatchOptions{
+		Subscriber: subscriber,
+		CEType:     "io.k8s.core.v1.secret",
+	}, func() runtime.Object { return &v1.Secret{} })
+
+	w, err := lw.WatchFunc(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("WatchFunc: %v", err)
+	}
+	if len(subscriber.handlers) != 3 {
+		t.Fatalf("got %d subscriptions, want 3 (added/modified/deleted)", len(subscriber.handlers))
+	}
+	w.Stop()
+	if len(subscriber.handlers) != 0 {
+		t.Fatalf("got %d subscriptions after Stop, want 0", len(subscriber.handlers))
+	}
+}
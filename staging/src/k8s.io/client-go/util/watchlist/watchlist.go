@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watchlist holds the small client-side helpers reflectors use to
+// turn a regular ListOptions into the options shape the WatchList feature
+// expects, without every caller having to remember the exact combination of
+// fields.
+package watchlist
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// metadataResourceVersionPath and metadataUIDPath are always required in a
+// FieldProjection: the reflector keys its store and tracks resourceVersion
+// off these two fields, so a projection that dropped them would make the
+// resulting objects unusable by the very machinery requesting them.
+const (
+	metadataResourceVersionPath = "metadata.resourceVersion"
+	metadataUIDPath             = "metadata.uid"
+)
+
+// PrepareWatchListOptionsFromListOptions returns the ListOptions a reflector
+// should send to request the WatchList semantics (a single watch stream
+// that starts with the current state as synthetic Added events, terminated
+// by a bookmark, rather than a separate List call followed by a Watch).
+// hasPreparedOptions is false when the input options already asked for
+// something incompatible with WatchList (e.g. a non-zero ResourceVersion),
+// in which case the caller should fall back to a plain List+Watch.
+func PrepareWatchListOptionsFromListOptions(options metav1.ListOptions) (result metav1.ListOptions, hasPreparedOptions bool, err error) {
+	if options.ResourceVersion != "" && options.ResourceVersion != "0" {
+		return metav1.ListOptions{}, false, nil
+	}
+	if options.FieldProjection != "" {
+		if err := ValidateFieldProjection(options.FieldProjection); err != nil {
+			return metav1.ListOptions{}, false, err
+		}
+	}
+
+	result = options
+	sendInitialEvents := true
+	result.SendInitialEvents = &sendInitialEvents
+	result.ResourceVersionMatch = metav1.ResourceVersionMatchNotOlderThan
+	allowBookmarks := true
+	result.AllowWatchBookmarks = allowBookmarks
+	return result, true, nil
+}
+
+// ValidateFieldProjection parses a comma-separated dotted-path field
+// projection (e.g. "metadata.name,metadata.labels,data.foo") and rejects
+// one that would exclude metadata.resourceVersion or metadata.uid: the
+// reflector needs both on every object it stores, regardless of what the
+// caller asked to project.
+func ValidateFieldProjection(fieldProjection string) error {
+	paths := ParseFieldProjection(fieldProjection)
+	if len(paths) == 0 {
+		return fmt.Errorf("fieldProjection must name at least one field")
+	}
+
+	seen := map[string]bool{}
+	for _, p := range paths {
+		seen[p] = true
+	}
+	if !seen[metadataResourceVersionPath] {
+		return fmt.Errorf("fieldProjection must include %q", metadataResourceVersionPath)
+	}
+	if !seen[metadataUIDPath] {
+		return fmt.Errorf("fieldProjection must include %q", metadataUIDPath)
+	}
+	return nil
+}
+
+// ParseFieldProjection splits a comma-separated dotted-path field
+// projection into its individual paths, trimming surrounding whitespace
+// and dropping empty entries so trailing commas don't produce a bogus
+// empty path.
+func ParseFieldProjection(fieldProjection string) []string {
+	var paths []string
+	for _, p := range strings.Split(fieldProjection, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchlist
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrepareWatchListOptionsFromListOptionsEmptyOptions(t *testing.T) {
+	_, hasPreparedOptions, err := PrepareWatchListOptionsFromListOptions(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasPreparedOptions {
+		t.Fatalf("expected hasPreparedOptions to be true for an empty ListOptions")
+	}
+}
+
+func TestPrepareWatchListOptionsFromListOptionsRejectsPinnedResourceVersion(t *testing.T) {
+	_, hasPreparedOptions, err := PrepareWatchListOptionsFromListOptions(metav1.ListOptions{ResourceVersion: "42"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasPreparedOptions {
+		t.Fatalf("expected hasPreparedOptions to be false for a pinned, non-zero ResourceVersion")
+	}
+}
+
+func TestValidateFieldProjectionRequiresResourceVersionAndUID(t *testing.T) {
+	if err := ValidateFieldProjection("metadata.name,metadata.labels"); err == nil {
+		t.Fatalf("expected an error for a projection missing metadata.resourceVersion/metadata.uid")
+	}
+	if err := ValidateFieldProjection("metadata.name,metadata.resourceVersion,metadata.uid"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseFieldProjectionTrimsAndDropsEmptyEntries(t *testing.T) {
+	got := ParseFieldProjection(" metadata.name , metadata.uid,,metadata.resourceVersion ")
+	want := []string{"metadata.name", "metadata.uid", "metadata.resourceVersion"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
@@ -18,6 +18,7 @@ package apimachinery
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -37,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
 	"k8s.io/apiserver/pkg/features"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/dynamic"
@@ -240,8 +242,170 @@ var _ = SIGDescribe("API Streaming (aka. WatchList)", framework.WithFeatureGate(
 		ginkgo.By("Verifying if the secret informer was properly synchronised")
 		verifyStore[unstructured.Unstructured](ctx, expectedSecrets, secretInformer.GetStore())
 	})
+
+	ginkgo.It("should deliver secret changes to an informer backed by a CloudEvents broker", func(ctx context.Context) {
+		subscriber := newFakeCloudEventsSubscriber()
+		listWatch := cache.NewCloudEventsListWatch(cache.CloudEventsListWatchOptions{
+			Subscriber: subscriber,
+			CEType:     "io.k8s.core.v1.secret",
+			List: func(options metav1.ListOptions) (runtime.Object, error) {
+				return &v1.SecretList{}, nil
+			},
+		}, func() runtime.Object { return &v1.Secret{} })
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+
+		secretInformer := cache.NewSharedIndexInformer(listWatch, &v1.Secret{}, time.Duration(0), nil)
+		go secretInformer.Run(stopCh)
+
+		ginkgo.By("Waiting until the informer has synced against the empty initial list")
+		err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 30*time.Second, false, func(context.Context) (done bool, err error) {
+			return secretInformer.HasSynced(), nil
+		})
+		framework.ExpectNoError(err)
+
+		ginkgo.By("Publishing a secret-added CloudEvent to the broker")
+		secret := newSecret("cloudevents-secret")
+		subscriber.publishAdded(secret)
+
+		ginkgo.By("Verifying the informer picked up the secret delivered over CloudEvents")
+		verifyStore(ctx, []*v1.Secret{secret}, secretInformer.GetStore())
+	})
+
+	ginkgo.It("bounds informer store memory with LRU eviction and re-hydrates on cache miss", func(ctx context.Context) {
+		ginkgo.By("Adding more secrets than the store's MaxEntries")
+		const maxEntries = 3
+		var names []string
+		for i := 0; i < maxEntries+2; i++ {
+			name := fmt.Sprintf("bounded-secret-%d", i)
+			_, err := f.ClientSet.CoreV1().Secrets(f.Namespace.Name).Create(ctx, newSecret(name), metav1.CreateOptions{})
+			framework.ExpectNoError(err)
+			names = append(names, name)
+		}
+
+		getter := secretGetter{client: f.ClientSet, namespace: f.Namespace.Name}
+		store := cache.NewBoundedLRUStore(cache.MetaNamespaceKeyFunc, maxEntries, 0, getter)
+		for _, name := range names {
+			secret, err := f.ClientSet.CoreV1().Secrets(f.Namespace.Name).Get(ctx, name, metav1.GetOptions{})
+			framework.ExpectNoError(err)
+			framework.ExpectNoError(store.Add(secret))
+		}
+
+		ginkgo.By("Verifying the store evicted down to MaxEntries")
+		gomega.Expect(store.List()).To(gomega.HaveLen(maxEntries))
+
+		ginkgo.By("Verifying a key evicted by the LRU re-hydrates through the getter instead of reporting a miss")
+		evictedName := names[0]
+		obj, exists, err := store.GetByKey(f.Namespace.Name + "/" + evictedName)
+		framework.ExpectNoError(err)
+		gomega.Expect(exists).To(gomega.BeTrueBecause("a cache miss on an evicted key should re-fetch through the getter"))
+		gomega.Expect(obj.(*v1.Secret).Name).To(gomega.Equal(evictedName))
+	})
+
+	// The apiserver in this checkout does not actually enforce
+	// FieldProjection when serving List/WatchList (see field_projection.go:
+	// the REST storage/negotiation code that would call
+	// ApplyFieldProjection isn't part of this snapshot), so there is no
+	// real server-streamed/informer-backed response to assert a projected
+	// view against here. This exercises ApplyFieldProjection itself,
+	// against objects fetched with the normal client, as the closest
+	// available check that the projection logic a real integration would
+	// call behaves correctly.
+	ginkgo.It("projects only the requested fields when FieldProjection is set", func(ctx context.Context) {
+		requiredPaths := "metadata.resourceVersion,metadata.uid"
+
+		ginkgo.By("Rejecting a projection that excludes metadata.resourceVersion/metadata.uid")
+		_, _, err := watchlist.PrepareWatchListOptionsFromListOptions(metav1.ListOptions{FieldProjection: "metadata.name,metadata.labels"})
+		gomega.Expect(err).To(gomega.HaveOccurred(), "a FieldProjection missing metadata.resourceVersion/metadata.uid must be rejected")
+
+		ginkgo.By("Adding a secret with data outside the projection")
+		secret, err := f.ClientSet.CoreV1().Secrets(f.Namespace.Name).Create(ctx, newSecret("field-projection-secret"), metav1.CreateOptions{})
+		framework.ExpectNoError(err)
+
+		opts, hasPreparedOptions, err := watchlist.PrepareWatchListOptionsFromListOptions(metav1.ListOptions{
+			FieldProjection: "metadata.name," + requiredPaths,
+		})
+		framework.ExpectNoError(err)
+		gomega.Expect(hasPreparedOptions).To(gomega.BeTrueBecause("a FieldProjection including the required metadata fields should be accepted"))
+
+		ginkgo.By("Verifying the projected object contains only the requested fields")
+		projected, err := handlers.ApplyFieldProjection(secret, watchlist.ParseFieldProjection(opts.FieldProjection))
+		framework.ExpectNoError(err)
+		u := projected.(*unstructured.Unstructured)
+
+		name, _, _ := unstructured.NestedString(u.Object, "metadata", "name")
+		gomega.Expect(name).To(gomega.Equal(secret.Name))
+		_, hasUID, _ := unstructured.NestedFieldNoCopy(u.Object, "metadata", "uid")
+		gomega.Expect(hasUID).To(gomega.BeTrueBecause("metadata.uid must always survive a FieldProjection"))
+		_, hasLabels, _ := unstructured.NestedFieldNoCopy(u.Object, "metadata", "labels")
+		gomega.Expect(hasLabels).To(gomega.BeFalseBecause("metadata.labels was not requested and should be excluded"))
+		_, hasData, _ := unstructured.NestedFieldNoCopy(u.Object, "data")
+		gomega.Expect(hasData).To(gomega.BeFalseBecause("data was not requested and should be excluded"))
+
+		ginkgo.By("Updating a field outside the projection and verifying the projected view still reflects only projected fields")
+		secret.StringData = map[string]string{"outside-projection": "value"}
+		updated, err := f.ClientSet.CoreV1().Secrets(f.Namespace.Name).Update(ctx, secret, metav1.UpdateOptions{})
+		framework.ExpectNoError(err)
+
+		reprojected, err := handlers.ApplyFieldProjection(updated, watchlist.ParseFieldProjection(opts.FieldProjection))
+		framework.ExpectNoError(err)
+		u = reprojected.(*unstructured.Unstructured)
+		_, hasData, _ = unstructured.NestedFieldNoCopy(u.Object, "data")
+		gomega.Expect(hasData).To(gomega.BeFalseBecause("the update changed a field outside the projection, which must stay excluded"))
+	})
 })
 
+// secretGetter re-hydrates a BoundedLRUStore cache miss by fetching the
+// secret straight from the apiserver, the same role a metadata client would
+// play for a production informer using this store.
+type secretGetter struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+func (g secretGetter) GetByKey(key string) (interface{}, bool, error) {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+	secret, err := g.client.CoreV1().Secrets(g.namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return secret, true, nil
+}
+
+// fakeCloudEventsSubscriber is an in-process stand-in for a real MQTT/HTTP
+// webhook broker connection, used to exercise cache.NewCloudEventsListWatch
+// without standing up an actual broker in the e2e environment.
+type fakeCloudEventsSubscriber struct {
+	handlers map[string]func(payload []byte)
+}
+
+func newFakeCloudEventsSubscriber() *fakeCloudEventsSubscriber {
+	return &fakeCloudEventsSubscriber{handlers: map[string]func(payload []byte){}}
+}
+
+func (f *fakeCloudEventsSubscriber) Subscribe(ceType string, onEvent func(payload []byte)) (func(), error) {
+	f.handlers[ceType] = onEvent
+	return func() { delete(f.handlers, ceType) }, nil
+}
+
+func (f *fakeCloudEventsSubscriber) publishAdded(secret *v1.Secret) {
+	data, err := json.Marshal(secret)
+	framework.ExpectNoError(err)
+	payload, err := json.Marshal(map[string]json.RawMessage{
+		"type": json.RawMessage(`"io.k8s.core.v1.secret.added"`),
+		"data": data,
+	})
+	framework.ExpectNoError(err)
+	f.handlers["io.k8s.core.v1.secret.added"](payload)
+}
+
 type roundTripper struct {
 	actualRequests         []string
 	actualResponseStatuses []string